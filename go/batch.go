@@ -0,0 +1,314 @@
+package ucotron
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBatchMaxItems      = 100
+	defaultBatchFlushInterval = 5 * time.Second
+	defaultBatchQueueSize     = 1000
+)
+
+// BatchOptions are optional parameters for AddMemoryBatch.
+type BatchOptions struct {
+	Namespace string
+	CallOptions
+}
+
+// BatchItemResult is the outcome of a single item within a batch ingestion
+// request. Error is set (and the other fields left zero) when that specific
+// item failed, so that one bad item doesn't fail the whole batch.
+type BatchItemResult struct {
+	ChunkNodeIDs  []int64          `json:"chunk_node_ids,omitempty"`
+	EntityNodeIDs []int64          `json:"entity_node_ids,omitempty"`
+	EdgesCreated  int              `json:"edges_created,omitempty"`
+	Metrics       IngestionMetrics `json:"metrics,omitempty"`
+	Error         string           `json:"error,omitempty"`
+}
+
+// BatchResponse is the response from AddMemoriesBatch: a parallel slice of
+// per-item results, so that one bad item doesn't fail the whole batch.
+type BatchResponse struct {
+	Results []BatchItemResult
+}
+
+// AddMemoriesBatch is AddMemoryBatch wrapped in a BatchResponse envelope,
+// for callers that prefer a named result type over a bare slice.
+func (c *Client) AddMemoriesBatch(ctx context.Context, items []CreateMemoryRequest, opts *BatchOptions) (*BatchResponse, error) {
+	results, err := c.AddMemoryBatch(ctx, items, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchResponse{Results: results}, nil
+}
+
+// AddMemoryBatch ingests multiple texts in a single HTTP round trip. The
+// response is a parallel slice of per-item results: a failure in one item's
+// Error field does not prevent the others from succeeding.
+func (c *Client) AddMemoryBatch(ctx context.Context, items []CreateMemoryRequest, opts *BatchOptions) ([]BatchItemResult, error) {
+	namespace := ""
+	var co CallOptions
+	if opts != nil {
+		namespace = opts.Namespace
+		co = opts.CallOptions
+	}
+	ctx, cancel := withCallTimeout(ctx, co)
+	defer cancel()
+
+	data, err := c.doRequest(ctx, http.MethodPost, "/api/v1/memories:batch", items, namespace, co)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []BatchItemResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse batch response: %w", err)
+	}
+	for _, r := range results {
+		c.observer.ObserveIngestion(r.Metrics)
+	}
+	return results, nil
+}
+
+// BatchIngesterOptions configures a BatchIngester.
+type BatchIngesterOptions struct {
+	// MaxItems is the number of queued items (per namespace) that triggers
+	// an immediate flush (default: 100).
+	MaxItems int
+	// FlushInterval is the maximum time an item waits in the queue before
+	// being flushed, regardless of MaxItems (default: 5s).
+	FlushInterval time.Duration
+	// QueueSize bounds the number of items Add() will buffer before it
+	// blocks the caller as backpressure (default: 1000).
+	QueueSize int
+}
+
+type batchQueueItem struct {
+	req       CreateMemoryRequest
+	namespace string
+	result    chan batchOutcome
+}
+
+type batchOutcome struct {
+	res BatchItemResult
+	err error
+}
+
+type flushSignal struct {
+	done chan struct{}
+}
+
+// BatchIngester accumulates AddMemory calls and flushes them as batched
+// AddMemoryBatch requests, either when MaxItems is reached for a namespace
+// or when FlushInterval elapses. Items with different namespaces are never
+// merged into the same HTTP request. A BatchIngester is safe for concurrent
+// use and must be closed with Close to release its worker goroutine.
+type BatchIngester struct {
+	client        *Client
+	maxItems      int
+	flushInterval time.Duration
+
+	queue   chan *batchQueueItem
+	flushCh chan flushSignal
+	done    chan struct{}
+	wg      sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// NewBatchIngester creates a BatchIngester bound to c. If opts is nil,
+// defaults are used.
+func (c *Client) NewBatchIngester(opts *BatchIngesterOptions) *BatchIngester {
+	maxItems := defaultBatchMaxItems
+	flushInterval := defaultBatchFlushInterval
+	queueSize := defaultBatchQueueSize
+
+	if opts != nil {
+		if opts.MaxItems > 0 {
+			maxItems = opts.MaxItems
+		}
+		if opts.FlushInterval > 0 {
+			flushInterval = opts.FlushInterval
+		}
+		if opts.QueueSize > 0 {
+			queueSize = opts.QueueSize
+		}
+	}
+
+	bi := &BatchIngester{
+		client:        c,
+		maxItems:      maxItems,
+		flushInterval: flushInterval,
+		queue:         make(chan *batchQueueItem, queueSize),
+		flushCh:       make(chan flushSignal),
+		done:          make(chan struct{}),
+	}
+
+	bi.wg.Add(1)
+	go bi.run()
+	return bi
+}
+
+// Add enqueues text for ingestion and blocks until it has been flushed to
+// the server (either because a batch filled up or FlushInterval elapsed),
+// returning that item's individual result. It also blocks, as backpressure,
+// if the ingester's internal queue is full.
+func (bi *BatchIngester) Add(ctx context.Context, text string, opts *AddMemoryOptions) (*BatchItemResult, error) {
+	namespace := ""
+	var metadata map[string]interface{}
+	if opts != nil {
+		namespace = opts.Namespace
+		metadata = opts.Metadata
+	}
+
+	item := &batchQueueItem{
+		req:       CreateMemoryRequest{Text: text, Metadata: metadata},
+		namespace: namespace,
+		result:    make(chan batchOutcome, 1),
+	}
+
+	select {
+	case bi.queue <- item:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-bi.done:
+		return nil, fmt.Errorf("ucotron: batch ingester is closed")
+	}
+
+	select {
+	case outcome := <-item.result:
+		if outcome.err != nil {
+			return nil, outcome.err
+		}
+		return &outcome.res, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Flush forces all currently queued items to be sent immediately, waiting
+// for the flush (and the resulting HTTP requests) to complete.
+func (bi *BatchIngester) Flush(ctx context.Context) error {
+	sig := flushSignal{done: make(chan struct{})}
+	select {
+	case bi.flushCh <- sig:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-bi.done:
+		return fmt.Errorf("ucotron: batch ingester is closed")
+	}
+
+	select {
+	case <-sig.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close flushes any remaining queued items and stops the worker goroutine.
+// It is safe to call Close multiple times.
+func (bi *BatchIngester) Close() error {
+	var err error
+	bi.closeOnce.Do(func() {
+		close(bi.done)
+		bi.wg.Wait()
+	})
+	return err
+}
+
+func (bi *BatchIngester) run() {
+	defer bi.wg.Done()
+
+	pending := make(map[string][]*batchQueueItem)
+	timer := time.NewTimer(bi.flushInterval)
+	defer timer.Stop()
+
+	flushAll := func() {
+		for ns := range pending {
+			bi.flushNamespace(ns, pending)
+		}
+	}
+
+	drainQueue := func() {
+		for {
+			select {
+			case item := <-bi.queue:
+				pending[item.namespace] = append(pending[item.namespace], item)
+				continue
+			default:
+			}
+			break
+		}
+	}
+
+	for {
+		select {
+		case item := <-bi.queue:
+			ns := item.namespace
+			pending[ns] = append(pending[ns], item)
+			if len(pending[ns]) >= bi.maxItems {
+				bi.flushNamespace(ns, pending)
+			}
+
+		case <-timer.C:
+			flushAll()
+			timer.Reset(bi.flushInterval)
+
+		case sig := <-bi.flushCh:
+			// Add enqueues onto bi.queue before Flush sends on flushCh, but
+			// select doesn't prefer whichever case has been ready longer, so
+			// without draining first a just-Add'ed item can lose the race
+			// and be skipped by the very flush it should have been part of.
+			drainQueue()
+			flushAll()
+			close(sig.done)
+
+		case <-bi.done:
+			drainQueue()
+			flushAll()
+			return
+		}
+	}
+}
+
+// flushNamespace sends every item queued for ns as one AddMemoryBatch
+// request and delivers each item's outcome back to its caller.
+func (bi *BatchIngester) flushNamespace(ns string, pending map[string][]*batchQueueItem) {
+	items := pending[ns]
+	delete(pending, ns)
+	if len(items) == 0 {
+		return
+	}
+
+	reqs := make([]CreateMemoryRequest, len(items))
+	for i, it := range items {
+		reqs[i] = it.req
+	}
+
+	results, err := bi.client.AddMemoryBatch(context.Background(), reqs, &BatchOptions{Namespace: ns})
+	if err != nil {
+		for _, it := range items {
+			it.result <- batchOutcome{err: err}
+		}
+		return
+	}
+
+	for i, it := range items {
+		if i >= len(results) {
+			it.result <- batchOutcome{err: fmt.Errorf("ucotron: server returned no result for batch item %d", i)}
+			continue
+		}
+		res := results[i]
+		if res.Error != "" {
+			it.result <- batchOutcome{err: fmt.Errorf("ucotron: batch item failed: %s", res.Error)}
+			continue
+		}
+		it.result <- batchOutcome{res: res}
+	}
+}
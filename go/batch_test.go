@@ -0,0 +1,177 @@
+package ucotron
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAddMemoryBatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/memories:batch" {
+			t.Errorf("expected /api/v1/memories:batch, got %s", r.URL.Path)
+		}
+		var reqs []CreateMemoryRequest
+		json.NewDecoder(r.Body).Decode(&reqs)
+		if len(reqs) != 2 {
+			t.Fatalf("expected 2 items, got %d", len(reqs))
+		}
+		json.NewEncoder(w).Encode([]BatchItemResult{
+			{ChunkNodeIDs: []int64{1}, EdgesCreated: 1},
+			{Error: "extraction failed"},
+		})
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	defer c.Close()
+
+	results, err := c.AddMemoryBatch(context.Background(), []CreateMemoryRequest{
+		{Text: "first"}, {Text: "second"},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].EdgesCreated != 1 {
+		t.Errorf("expected edges_created 1, got %d", results[0].EdgesCreated)
+	}
+	if results[1].Error != "extraction failed" {
+		t.Errorf("expected per-item error, got %q", results[1].Error)
+	}
+}
+
+func TestBatchIngesterFlushesOnMaxItems(t *testing.T) {
+	var batches [][]CreateMemoryRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []CreateMemoryRequest
+		json.NewDecoder(r.Body).Decode(&reqs)
+		batches = append(batches, reqs)
+		results := make([]BatchItemResult, len(reqs))
+		json.NewEncoder(w).Encode(results)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	defer c.Close()
+
+	ingester := c.NewBatchIngester(&BatchIngesterOptions{MaxItems: 2, FlushInterval: time.Hour})
+	defer ingester.Close()
+
+	ctx := context.Background()
+	results := make(chan error, 2)
+	go func() {
+		_, err := ingester.Add(ctx, "one", nil)
+		results <- err
+	}()
+	go func() {
+		_, err := ingester.Add(ctx, "two", nil)
+		results <- err
+	}()
+
+	for i := 0; i < 2; i++ {
+		if err := <-results; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(batches) != 1 || len(batches[0]) != 2 {
+		t.Errorf("expected exactly one batch of 2 items, got %v", batches)
+	}
+}
+
+func TestBatchIngesterNamespaceFanOut(t *testing.T) {
+	var mu = make(chan struct{}, 1)
+	seenNamespaces := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu <- struct{}{}
+		ns := r.Header.Get("X-Ucotron-Namespace")
+		var reqs []CreateMemoryRequest
+		json.NewDecoder(r.Body).Decode(&reqs)
+		seenNamespaces[ns] += len(reqs)
+		<-mu
+		results := make([]BatchItemResult, len(reqs))
+		json.NewEncoder(w).Encode(results)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	defer c.Close()
+
+	ingester := c.NewBatchIngester(&BatchIngesterOptions{MaxItems: 100, FlushInterval: 20 * time.Millisecond})
+
+	// Two items in different namespaces, added concurrently so they land in
+	// the same flush window, must still go out as separate requests.
+	ctx := context.Background()
+	addErrs := make(chan error, 2)
+	for _, ns := range []string{"ns1", "ns2"} {
+		go func(ns string) {
+			_, err := ingester.Add(ctx, "item in "+ns, &AddMemoryOptions{Namespace: ns})
+			addErrs <- err
+		}(ns)
+	}
+	for i := 0; i < 2; i++ {
+		if err := <-addErrs; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ingester.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if seenNamespaces["ns1"] != 1 {
+		t.Errorf("expected 1 item flushed for ns1, got %d", seenNamespaces["ns1"])
+	}
+	if seenNamespaces["ns2"] != 1 {
+		t.Errorf("expected 1 item flushed for ns2, got %d", seenNamespaces["ns2"])
+	}
+}
+
+func TestBatchIngesterFlush(t *testing.T) {
+	var flushed int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []CreateMemoryRequest
+		json.NewDecoder(r.Body).Decode(&reqs)
+		flushed += int32(len(reqs))
+		results := make([]BatchItemResult, len(reqs))
+		json.NewEncoder(w).Encode(results)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	defer c.Close()
+
+	ingester := c.NewBatchIngester(&BatchIngesterOptions{MaxItems: 100, FlushInterval: time.Hour})
+	defer ingester.Close()
+
+	// Enqueue directly rather than through Add (which blocks for the
+	// result) so the item is guaranteed to already be sitting in
+	// ingester.queue before Flush races against it below.
+	item := &batchQueueItem{
+		req:    CreateMemoryRequest{Text: "pending"},
+		result: make(chan batchOutcome, 1),
+	}
+	ingester.queue <- item
+
+	if err := ingester.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case outcome := <-item.result:
+		if outcome.err != nil {
+			t.Fatal(outcome.err)
+		}
+	default:
+		t.Fatal("expected the queued item to have been flushed")
+	}
+
+	if flushed != 1 {
+		t.Errorf("expected 1 item flushed, got %d", flushed)
+	}
+}
@@ -0,0 +1,216 @@
+package ucotron
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BatchItemOutcome is the eventual result of one item queued through
+// Batcher.Add, delivered on the future channel Add returns.
+type BatchItemOutcome struct {
+	Result BatchItemResult
+	Err    error
+}
+
+type batcherItem struct {
+	req       CreateMemoryRequest
+	namespace string
+	future    chan BatchItemOutcome
+}
+
+// Batcher pipelines AddMemory calls into batched AddMemoriesBatch requests,
+// flushing whenever maxItems accumulate for a namespace or flushEvery
+// elapses since the timer was last reset, whichever comes first. Items
+// with different namespaces are never merged into the same HTTP request,
+// mirroring BatchIngester. Unlike BatchIngester, Add does not block for the
+// flush to complete — it returns a future channel immediately, so a caller
+// can pipeline many Add calls before collecting results. A Batcher is safe
+// for concurrent use and must be closed with Close to release its worker
+// goroutine.
+type Batcher struct {
+	client        *Client
+	maxItems      int
+	flushInterval time.Duration
+
+	queue     chan *batcherItem
+	flushCh   chan flushSignal
+	done      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// NewBatcher creates a Batcher bound to c. maxItems <= 0 and flushEvery <=
+// 0 fall back to the same defaults as BatchIngester.
+func (c *Client) NewBatcher(flushEvery time.Duration, maxItems int) *Batcher {
+	if maxItems <= 0 {
+		maxItems = defaultBatchMaxItems
+	}
+	if flushEvery <= 0 {
+		flushEvery = defaultBatchFlushInterval
+	}
+
+	b := &Batcher{
+		client:        c,
+		maxItems:      maxItems,
+		flushInterval: flushEvery,
+		queue:         make(chan *batcherItem, defaultBatchQueueSize),
+		flushCh:       make(chan flushSignal),
+		done:          make(chan struct{}),
+	}
+
+	b.wg.Add(1)
+	go b.run()
+	return b
+}
+
+// Add enqueues text for ingestion and returns immediately with a future
+// that resolves once the item has been flushed to the server (either
+// because the batch filled up or flushEvery elapsed), carrying that item's
+// individual result or error. It blocks only as backpressure if the
+// Batcher's internal queue is full, or returns ctx.Err() if ctx is done
+// first.
+func (b *Batcher) Add(ctx context.Context, text string, opts *AddMemoryOptions) (<-chan BatchItemOutcome, error) {
+	var namespace string
+	var metadata map[string]interface{}
+	if opts != nil {
+		namespace = opts.Namespace
+		metadata = opts.Metadata
+	}
+
+	item := &batcherItem{
+		req:       CreateMemoryRequest{Text: text, Metadata: metadata},
+		namespace: namespace,
+		future:    make(chan BatchItemOutcome, 1),
+	}
+
+	select {
+	case b.queue <- item:
+		return item.future, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-b.done:
+		return nil, fmt.Errorf("ucotron: batcher is closed")
+	}
+}
+
+// Flush forces all currently queued items to be sent immediately, waiting
+// for the flush (and the resulting HTTP request) to complete.
+func (b *Batcher) Flush(ctx context.Context) error {
+	sig := flushSignal{done: make(chan struct{})}
+	select {
+	case b.flushCh <- sig:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-b.done:
+		return fmt.Errorf("ucotron: batcher is closed")
+	}
+
+	select {
+	case <-sig.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close flushes any remaining queued items and stops the worker goroutine.
+// It is safe to call Close multiple times.
+func (b *Batcher) Close() error {
+	b.closeOnce.Do(func() {
+		close(b.done)
+		b.wg.Wait()
+	})
+	return nil
+}
+
+func (b *Batcher) run() {
+	defer b.wg.Done()
+
+	pending := make(map[string][]*batcherItem)
+	timer := time.NewTimer(b.flushInterval)
+	defer timer.Stop()
+
+	flushAll := func() {
+		for ns := range pending {
+			b.flushNamespace(ns, pending)
+		}
+	}
+
+	drainQueue := func() {
+		for {
+			select {
+			case item := <-b.queue:
+				pending[item.namespace] = append(pending[item.namespace], item)
+				continue
+			default:
+			}
+			break
+		}
+	}
+
+	for {
+		select {
+		case item := <-b.queue:
+			pending[item.namespace] = append(pending[item.namespace], item)
+			if len(pending[item.namespace]) >= b.maxItems {
+				b.flushNamespace(item.namespace, pending)
+			}
+
+		case <-timer.C:
+			flushAll()
+			timer.Reset(b.flushInterval)
+
+		case sig := <-b.flushCh:
+			// Add enqueues onto b.queue before Flush sends on flushCh, but
+			// select doesn't prefer whichever case has been ready longer, so
+			// without draining first a just-Add'ed item can lose the race
+			// and be skipped by the very flush it should have been part of.
+			drainQueue()
+			flushAll()
+			close(sig.done)
+
+		case <-b.done:
+			drainQueue()
+			flushAll()
+			return
+		}
+	}
+}
+
+// flushNamespace sends every item queued for ns as one AddMemoriesBatch
+// request and delivers each item's outcome to its future.
+func (b *Batcher) flushNamespace(ns string, pending map[string][]*batcherItem) {
+	items := pending[ns]
+	delete(pending, ns)
+	if len(items) == 0 {
+		return
+	}
+
+	reqs := make([]CreateMemoryRequest, len(items))
+	for i, it := range items {
+		reqs[i] = it.req
+	}
+
+	resp, err := b.client.AddMemoriesBatch(context.Background(), reqs, &BatchOptions{Namespace: ns})
+	if err != nil {
+		for _, it := range items {
+			it.future <- BatchItemOutcome{Err: err}
+		}
+		return
+	}
+
+	for i, it := range items {
+		if i >= len(resp.Results) {
+			it.future <- BatchItemOutcome{Err: fmt.Errorf("ucotron: server returned no result for batch item %d", i)}
+			continue
+		}
+		res := resp.Results[i]
+		if res.Error != "" {
+			it.future <- BatchItemOutcome{Result: res, Err: fmt.Errorf("ucotron: batch item failed: %s", res.Error)}
+			continue
+		}
+		it.future <- BatchItemOutcome{Result: res}
+	}
+}
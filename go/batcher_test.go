@@ -0,0 +1,143 @@
+package ucotron
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBatcherFlushesOnMaxItems(t *testing.T) {
+	var batches [][]CreateMemoryRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []CreateMemoryRequest
+		json.NewDecoder(r.Body).Decode(&reqs)
+		batches = append(batches, reqs)
+		results := make([]BatchItemResult, len(reqs))
+		json.NewEncoder(w).Encode(results)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	defer c.Close()
+
+	b := c.NewBatcher(time.Hour, 2)
+	defer b.Close()
+
+	f1, err := b.Add(context.Background(), "one", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f2, err := b.Add(context.Background(), "two", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range []<-chan BatchItemOutcome{f1, f2} {
+		select {
+		case out := <-f:
+			if out.Err != nil {
+				t.Errorf("unexpected item error: %v", out.Err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for batch flush")
+		}
+	}
+
+	if len(batches) != 1 || len(batches[0]) != 2 {
+		t.Fatalf("expected one batch of 2 items, got %v", batches)
+	}
+}
+
+func TestBatcherPartialFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]BatchItemResult{
+			{EdgesCreated: 1},
+			{Error: "extraction failed"},
+		})
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	defer c.Close()
+
+	b := c.NewBatcher(time.Hour, 2)
+	defer b.Close()
+
+	fGood, err := b.Add(context.Background(), "good", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fBad, err := b.Add(context.Background(), "bad", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	good := <-fGood
+	if good.Err != nil || good.Result.EdgesCreated != 1 {
+		t.Errorf("expected the first item to succeed, got %+v", good)
+	}
+	bad := <-fBad
+	if bad.Err == nil {
+		t.Error("expected the second item to surface its per-item error")
+	}
+}
+
+func TestBatcherAddRespectsContextCancellation(t *testing.T) {
+	// An unbuffered queue with no running worker means Add can never
+	// enqueue, forcing it down the ctx.Done() path deterministically.
+	b := &Batcher{
+		maxItems:      1,
+		flushInterval: time.Hour,
+		queue:         make(chan *batcherItem),
+		flushCh:       make(chan flushSignal),
+		done:          make(chan struct{}),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := b.Add(ctx, "text", nil); err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestBatcherFlush(t *testing.T) {
+	var flushes int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flushes++
+		var reqs []CreateMemoryRequest
+		json.NewDecoder(r.Body).Decode(&reqs)
+		json.NewEncoder(w).Encode(make([]BatchItemResult, len(reqs)))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	defer c.Close()
+
+	b := c.NewBatcher(time.Hour, 100)
+	defer b.Close()
+
+	future, err := b.Add(context.Background(), "text", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if flushes != 1 {
+		t.Fatalf("expected Flush to trigger exactly 1 HTTP request, got %d", flushes)
+	}
+
+	select {
+	case out := <-future:
+		if out.Err != nil {
+			t.Errorf("unexpected item error: %v", out.Err)
+		}
+	default:
+		t.Error("expected the future to already be resolved after Flush returned")
+	}
+}
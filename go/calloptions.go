@@ -0,0 +1,67 @@
+package ucotron
+
+import (
+	"context"
+	"time"
+)
+
+// CallOptions holds per-call overrides that compose with the ctx passed to
+// a Client method, letting a single call use a tighter deadline than the
+// client's default TimeoutMs without affecting any other call. Embed it
+// into an *Options struct to use it.
+type CallOptions struct {
+	// Timeout bounds this call, all its attempts and retries together, to
+	// the given duration. The client's TimeoutMs still bounds each HTTP
+	// attempt, so Timeout can shorten that limit but not extend it.
+	Timeout time.Duration
+	// Deadline sets an absolute deadline for this call. If both Timeout and
+	// Deadline are set, whichever produces the earlier deadline wins.
+	Deadline time.Time
+	// IdempotencyKey, if set, is sent as the Idempotency-Key header instead
+	// of an auto-generated UUIDv4, letting a caller derive it from e.g. an
+	// upstream event ID.
+	IdempotencyKey string
+	// NonIdempotent opts a call out of automatic Idempotency-Key generation,
+	// for endpoints where replaying the same key would be unsafe.
+	NonIdempotent bool
+}
+
+// WithTimeout returns a CallOptions that bounds a single call to d, for
+// assigning to the CallOptions field of an *Options struct.
+func WithTimeout(d time.Duration) CallOptions {
+	return CallOptions{Timeout: d}
+}
+
+// WithDeadline returns a CallOptions that bounds a single call to the
+// absolute time t, for assigning to the CallOptions field of an *Options
+// struct.
+func WithDeadline(t time.Time) CallOptions {
+	return CallOptions{Deadline: t}
+}
+
+// WithIdempotencyKey returns a CallOptions that sends key as the
+// Idempotency-Key header instead of an auto-generated one, for assigning to
+// the CallOptions field of an *Options struct.
+func WithIdempotencyKey(key string) CallOptions {
+	return CallOptions{IdempotencyKey: key}
+}
+
+// withCallTimeout derives a context bounded by co, composing with whatever
+// deadline ctx already carries. The returned cancel func must always be
+// called by the caller, typically via defer.
+func withCallTimeout(ctx context.Context, co CallOptions) (context.Context, context.CancelFunc) {
+	switch {
+	case co.Timeout > 0 && !co.Deadline.IsZero():
+		deadline := co.Deadline
+		if byTimeout := time.Now().Add(co.Timeout); byTimeout.Before(deadline) {
+			deadline = byTimeout
+		}
+		return context.WithDeadline(ctx, deadline)
+	case co.Timeout > 0:
+		return context.WithTimeout(ctx, co.Timeout)
+	case !co.Deadline.IsZero():
+		return context.WithDeadline(ctx, co.Deadline)
+	default:
+		return ctx, func() {}
+	}
+}
@@ -0,0 +1,73 @@
+package ucotron
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithCallTimeoutUsesTimeout(t *testing.T) {
+	ctx, cancel := withCallTimeout(context.Background(), CallOptions{Timeout: 10 * time.Millisecond})
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("expected a deadline to be set")
+	}
+	if time.Until(deadline) > 10*time.Millisecond {
+		t.Errorf("expected deadline within 10ms, got %v away", time.Until(deadline))
+	}
+}
+
+func TestWithCallTimeoutNoOptions(t *testing.T) {
+	parent := context.Background()
+	ctx, cancel := withCallTimeout(parent, CallOptions{})
+	defer cancel()
+
+	if ctx != parent {
+		t.Error("expected the original context to be returned unchanged")
+	}
+}
+
+func TestWithCallTimeoutPicksEarlierOfTimeoutAndDeadline(t *testing.T) {
+	farDeadline := time.Now().Add(time.Hour)
+	ctx, cancel := withCallTimeout(context.Background(), CallOptions{Timeout: 10 * time.Millisecond, Deadline: farDeadline})
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("expected a deadline to be set")
+	}
+	if deadline.After(farDeadline) {
+		t.Errorf("expected the tighter timeout-derived deadline to win, got %v", deadline)
+	}
+}
+
+func TestSearchCallTimeoutExceeded(t *testing.T) {
+	// The server holds every request open until the client gives up, so the
+	// only way the call can end is the per-call timeout firing.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Drain the POST body first: only then does the server notice the
+		// client hanging up and cancel r.Context().
+		io.Copy(io.Discard, r.Body)
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, &ClientConfig{
+		Retry: &RetryConfig{MaxRetries: 0, BaseDelayMs: 1, MaxDelayMs: 1},
+	})
+	defer c.Close()
+
+	_, err := c.Search(context.Background(), "q", &SearchOptions{CallOptions: WithTimeout(10 * time.Millisecond)})
+	if err == nil {
+		t.Fatal("expected error from exceeded per-call timeout")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the per-call timeout to surface as context.DeadlineExceeded, got %T: %v", err, err)
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"io"
 	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -28,6 +30,19 @@ type RetryConfig struct {
 	BaseDelayMs int
 	// MaxDelayMs is the maximum delay in milliseconds (default: 5000).
 	MaxDelayMs int
+	// Policy, if set, overrides the legacy exponential backoff with a
+	// pluggable retry policy (see RetryPolicy). MaxDelayMs still caps every
+	// delay it returns; BaseDelayMs only feeds the legacy backoff used for a
+	// retry whose NextDelay returns false.
+	Policy RetryPolicy
+	// Jitter selects a built-in RetryPolicy shape (full or decorrelated
+	// jitter) built from BaseDelayMs/MaxDelayMs, without having to construct
+	// a Policy by hand. Ignored once Policy is set. Defaults to JitterNone,
+	// the legacy deterministic exponential backoff.
+	Jitter Jitter
+	// Clock abstracts time for the retry wait, so tests can exercise backoff
+	// without real delays. If nil, the real time package is used.
+	Clock Clock
 }
 
 // ClientConfig configures the Ucotron client.
@@ -38,20 +53,53 @@ type ClientConfig struct {
 	Retry *RetryConfig
 	// DefaultNamespace is the default namespace for all requests.
 	DefaultNamespace string
+	// Observer receives instrumentation events for every request, retry, and
+	// ingestion. If nil, no instrumentation is emitted.
+	Observer Observer
+	// Endpoints lists additional cluster members beyond serverURL. When set,
+	// the client becomes cluster-aware: it rotates among serverURL and
+	// Endpoints on connection failures and retryable server errors, sticking
+	// with whichever endpoint last succeeded. See Client.SyncEndpoints to
+	// refresh this list from the server at runtime.
+	Endpoints []string
+	// RateLimiter throttles outgoing requests, including retries. If nil
+	// and RatePerSec > 0, a TokenBucketLimiter built from RatePerSec/Burst
+	// is used instead.
+	RateLimiter RateLimiter
+	// RatePerSec and Burst configure the default TokenBucketLimiter when
+	// RateLimiter is nil. RatePerSec <= 0 disables rate limiting.
+	RatePerSec float64
+	Burst      int
+	// MaxInFlight caps the number of requests dispatched to the server
+	// concurrently (across all calls on this Client), queuing any beyond
+	// that limit. Zero means unlimited.
+	MaxInFlight int
 }
 
 // Client is a Ucotron server HTTP client.
 type Client struct {
-	baseURL          string
+	mu               sync.Mutex
+	endpoints        []string
+	pinnedIdx        int
 	httpClient       *http.Client
 	retryConfig      RetryConfig
 	defaultNamespace string
+	observer         Observer
+	rateLimiter      RateLimiter
+	inFlightSem      chan struct{}
+	stats            *clientStats
+	clock            Clock
 }
 
 // NewClient creates a new Ucotron client connected to the given server URL.
-// If config is nil, default configuration is used.
+// If config is nil, default configuration is used. If config.Endpoints is
+// also set, the client rotates across serverURL and those endpoints as a
+// cluster (see ClientConfig.Endpoints).
 func NewClient(serverURL string, config *ClientConfig) *Client {
-	serverURL = strings.TrimRight(serverURL, "/")
+	var endpoints []string
+	if serverURL != "" {
+		endpoints = append(endpoints, strings.TrimRight(serverURL, "/"))
+	}
 
 	timeoutMs := defaultTimeoutMs
 	retry := RetryConfig{
@@ -60,6 +108,7 @@ func NewClient(serverURL string, config *ClientConfig) *Client {
 		MaxDelayMs:  defaultMaxDelayMs,
 	}
 	defaultNS := "default"
+	var observer Observer = noopObserver{}
 
 	if config != nil {
 		if config.TimeoutMs > 0 {
@@ -75,19 +124,64 @@ func NewClient(serverURL string, config *ClientConfig) *Client {
 			if config.Retry.MaxDelayMs > 0 {
 				retry.MaxDelayMs = config.Retry.MaxDelayMs
 			}
+			if config.Retry.Policy != nil {
+				retry.Policy = config.Retry.Policy
+			}
+			if config.Retry.Jitter != "" {
+				retry.Jitter = config.Retry.Jitter
+			}
+			if config.Retry.Clock != nil {
+				retry.Clock = config.Retry.Clock
+			}
 		}
 		if config.DefaultNamespace != "" {
 			defaultNS = config.DefaultNamespace
 		}
+		if config.Observer != nil {
+			observer = config.Observer
+		}
+		for _, e := range config.Endpoints {
+			if e = strings.TrimRight(e, "/"); e != "" {
+				endpoints = append(endpoints, e)
+			}
+		}
+	}
+
+	var rateLimiter RateLimiter
+	var inFlightSem chan struct{}
+	if config != nil {
+		switch {
+		case config.RateLimiter != nil:
+			rateLimiter = config.RateLimiter
+		case config.RatePerSec > 0:
+			burst := config.Burst
+			if burst <= 0 {
+				burst = 1
+			}
+			rateLimiter = &TokenBucketLimiter{RatePerSec: config.RatePerSec, Burst: burst}
+		}
+		if config.MaxInFlight > 0 {
+			inFlightSem = make(chan struct{}, config.MaxInFlight)
+		}
+	}
+
+	clock := retry.Clock
+	if clock == nil {
+		clock = realClock{}
 	}
 
 	return &Client{
-		baseURL: serverURL,
+		endpoints: endpoints,
 		httpClient: &http.Client{
 			Timeout: time.Duration(timeoutMs) * time.Millisecond,
 		},
 		retryConfig:      retry,
 		defaultNamespace: defaultNS,
+		observer:         observer,
+		rateLimiter:      rateLimiter,
+		inFlightSem:      inFlightSem,
+		stats:            newClientStats(),
+		clock:            clock,
 	}
 }
 
@@ -104,6 +198,30 @@ func (c *Client) resolveNamespace(override string) string {
 	return c.defaultNamespace
 }
 
+// endpoint returns the currently pinned endpoint.
+func (c *Client) endpoint() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.endpoints[c.pinnedIdx]
+}
+
+// numEndpoints returns how many endpoints the client is configured with.
+func (c *Client) numEndpoints() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.endpoints)
+}
+
+// rotateEndpoint advances the pinned endpoint to the next one in the list.
+// It is called after a connection failure or retryable server error so the
+// next try targets a different cluster member; a successful try leaves the
+// pinned endpoint untouched, so it stays sticky across calls.
+func (c *Client) rotateEndpoint() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pinnedIdx = (c.pinnedIdx + 1) % len(c.endpoints)
+}
+
 // retryDelay computes the delay for the given attempt using exponential backoff.
 func (c *Client) retryDelay(attempt int) time.Duration {
 	delay := float64(c.retryConfig.BaseDelayMs) * math.Pow(2, float64(attempt))
@@ -113,111 +231,203 @@ func (c *Client) retryDelay(attempt int) time.Duration {
 	return time.Duration(delay) * time.Millisecond
 }
 
-// doRequest executes an HTTP request with retry logic.
-// It retries on 5xx errors and connection errors, but NOT on 4xx errors.
-func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, namespace string) ([]byte, error) {
-	url := c.baseURL + path
+// waitToRetry blocks until it is time for the next attempt, returning false
+// if ctx is canceled first. If attempt is already the last one, it returns
+// true immediately without waiting, since the caller's loop is about to end.
+func (c *Client) waitToRetry(ctx context.Context, attempt, maxAttempts int) bool {
+	if attempt >= maxAttempts-1 {
+		return true
+	}
+	select {
+	case <-ctx.Done():
+		return false
+	case <-c.clock.After(c.retryDelay(attempt)):
+		return true
+	}
+}
+
+// readErrorBody reads and closes resp.Body, parsing it into a
+// UcotronServerError. It is a thin alias for ParseServerError, kept so
+// call sites that only ever had a *http.Response (no pre-read body) don't
+// need to know about the exported name.
+func readErrorBody(resp *http.Response) *UcotronServerError {
+	return ParseServerError(resp)
+}
+
+// doRequest executes an HTTP request against the client's cluster of
+// endpoints. It retries on 5xx errors, 429 (rate limited), and connection
+// errors, but NOT on other 4xx errors. Within a single attempt it first
+// cycles through every configured endpoint — rotating the pinned endpoint
+// forward on each failure — before spending that attempt's retry backoff; a
+// try that succeeds leaves its endpoint pinned, so it stays sticky for the
+// next call. A caller-side context.Canceled or context.DeadlineExceeded is
+// surfaced as a *UcotronCanceledError (never as a retryable failure), so
+// callers can tell "I canceled it" apart from a genuine connection failure.
+//
+// A non-GET request is assigned an Idempotency-Key (either co.IdempotencyKey
+// or a generated UUIDv4) that is reused across every retry of that same
+// logical call, unless co.NonIdempotent opts out. Servers are expected to
+// honor the contract that replaying the same key returns the original
+// response rather than re-executing the operation.
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, namespace string, co CallOptions) ([]byte, error) {
 	ns := c.resolveNamespace(namespace)
+	idempotencyKey := idempotencyKeyFor(method, co)
+
+	release, err := c.acquireInFlight(ctx)
+	if err != nil {
+		return nil, wrapIfCtxErr(err)
+	}
+	defer release()
+
+	var reqBodyBytes []byte
+	if body != nil {
+		var err error
+		reqBodyBytes, err = json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+	}
 
 	var lastErr error
+	var lastResp *http.Response
+	var attemptErrs []error
+	endpointErrs := make(map[string]error)
 	maxAttempts := c.retryConfig.MaxRetries + 1
 
 	for attempt := 0; attempt < maxAttempts; attempt++ {
-		var reqBody io.Reader
-		if body != nil {
-			jsonBytes, err := json.Marshal(body)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		for try, numEndpoints := 0, c.numEndpoints(); try < numEndpoints; try++ {
+			if err := c.waitForRateLimit(ctx); err != nil {
+				return nil, wrapIfCtxErr(err)
 			}
-			reqBody = bytes.NewReader(jsonBytes)
-		}
 
-		req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create request: %w", err)
-		}
+			endpoint := c.endpoint()
+			attemptStart := time.Now()
 
-		req.Header.Set("Accept", "application/json")
-		if body != nil {
-			req.Header.Set("Content-Type", "application/json")
-		}
-		req.Header.Set(namespaceHeader, ns)
+			var reqBody io.Reader
+			if reqBodyBytes != nil {
+				reqBody = bytes.NewReader(reqBodyBytes)
+			}
 
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			lastErr = &UcotronConnectionError{
-				Message: fmt.Sprintf("request to %s %s failed", method, path),
-				Cause:   err,
+			req, err := http.NewRequestWithContext(ctx, method, endpoint+path, reqBody)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
 			}
-			if attempt < maxAttempts-1 {
-				select {
-				case <-ctx.Done():
-					return nil, ctx.Err()
-				case <-time.After(c.retryDelay(attempt)):
-				}
+
+			req.Header.Set("Accept", "application/json")
+			if reqBodyBytes != nil {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			req.Header.Set(namespaceHeader, ns)
+			if idempotencyKey != "" {
+				req.Header.Set(idempotencyKeyHeader, idempotencyKey)
 			}
-			continue
-		}
 
-		respBody, err := io.ReadAll(resp.Body)
-		resp.Body.Close()
-		if err != nil {
-			lastErr = &UcotronConnectionError{
-				Message: "failed to read response body",
-				Cause:   err,
+			resp, err := c.httpClient.Do(req)
+			if err != nil {
+				c.observer.ObserveRequest(method, path, "error", time.Since(attemptStart))
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return nil, wrapIfCtxErr(ctxErr)
+				}
+				lastErr = &UcotronConnectionError{
+					Message: fmt.Sprintf("request to %s %s failed", method, path),
+					Cause:   err,
+				}
+				lastResp = nil
+				endpointErrs[endpoint] = lastErr
+				c.rotateEndpoint()
+				continue
 			}
-			if attempt < maxAttempts-1 {
-				select {
-				case <-ctx.Done():
-					return nil, ctx.Err()
-				case <-time.After(c.retryDelay(attempt)):
+
+			respBody, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				c.observer.ObserveRequest(method, path, "error", time.Since(attemptStart))
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return nil, wrapIfCtxErr(ctxErr)
 				}
+				lastErr = &UcotronConnectionError{
+					Message: "failed to read response body",
+					Cause:   err,
+				}
+				lastResp = resp
+				endpointErrs[endpoint] = lastErr
+				c.rotateEndpoint()
+				continue
 			}
-			continue
-		}
 
-		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-			return respBody, nil
-		}
+			status := strconv.Itoa(resp.StatusCode)
+			c.observer.ObserveRequest(method, path, status, time.Since(attemptStart))
+
+			if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+				return respBody, nil
+			}
+
+			// Retry eligibility defaults to 5xx and 429 (rate limited), but a
+			// RetryConfig.Policy implementing RetryEligibility can override
+			// it in either direction (see retryEligible).
+			classifiedErr := classify(parseServerErrorWithHeader(resp.StatusCode, resp.Header, respBody))
+			if !c.retryEligible(attempt, resp, classifiedErr) {
+				return nil, classifiedErr
+			}
 
-		// 4xx errors are NOT retryable — return immediately
-		if resp.StatusCode >= 400 && resp.StatusCode < 500 {
-			serverErr := parseServerError(resp.StatusCode, respBody)
-			return nil, serverErr
+			// Retried: rotate to the next endpoint before spending this
+			// attempt's backoff.
+			lastErr = classifiedErr
+			lastResp = resp
+			endpointErrs[endpoint] = lastErr
+			c.rotateEndpoint()
 		}
 
-		// 5xx errors are retryable
-		lastErr = parseServerError(resp.StatusCode, respBody)
+		attemptErrs = append(attemptErrs, lastErr)
+
 		if attempt < maxAttempts-1 {
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(c.retryDelay(attempt)):
-			}
+			c.observer.ObserveRetry(attempt, lastErr)
+		}
+		if !c.waitToRetryPolicy(ctx, attempt, maxAttempts, lastResp, lastErr) {
+			return nil, wrapIfCtxErr(ctx.Err())
 		}
 	}
 
 	return nil, &UcotronRetriesExhaustedError{
-		Attempts:  maxAttempts,
-		LastError: lastErr,
+		attempts:       maxAttempts,
+		errs:           attemptErrs,
+		LastError:      lastErr,
+		EndpointErrors: endpointErrs,
 	}
 }
 
-// parseServerError attempts to parse the error body as APIErrorBody, falling back to status text.
+// parseServerError attempts to parse the error body as APIErrorBody, falling
+// back to status text. It picks up a request ID from the body's own
+// request_id field, but — unlike parseServerErrorWithHeader — has no
+// response headers available to prefer the X-Ucotron-Request-ID header.
 func parseServerError(statusCode int, body []byte) *UcotronServerError {
-	var apiErr APIErrorBody
-	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Message != "" {
-		return &UcotronServerError{
+	return parseServerErrorWithHeader(statusCode, nil, body)
+}
+
+// parseServerErrorWithHeader is parseServerError plus the response headers,
+// so the caller's own X-Ucotron-Request-ID (preferred over the body's
+// request_id field, same as ParseServerError) is honored. header may be nil.
+func parseServerErrorWithHeader(statusCode int, header http.Header, body []byte) *UcotronServerError {
+	var se *UcotronServerError
+	if code, message, data, requestID, ok := parseErrorBody(body); ok {
+		se = &UcotronServerError{
+			StatusCode: statusCode,
+			Code:       code,
+			Message:    message,
+			requestID:  requestID,
+			data:       data,
+		}
+	} else {
+		se = &UcotronServerError{
 			StatusCode: statusCode,
-			Code:       apiErr.Code,
-			Message:    apiErr.Message,
+			Code:       http.StatusText(statusCode),
+			Message:    string(body),
 		}
 	}
-	return &UcotronServerError{
-		StatusCode: statusCode,
-		Code:       http.StatusText(statusCode),
-		Message:    string(body),
+	if h := header.Get(requestIDHeader); h != "" {
+		se.requestID = h
 	}
+	return se
 }
 
 // --- Options Types ---
@@ -226,12 +436,14 @@ func parseServerError(statusCode int, body []byte) *UcotronServerError {
 type AugmentOptions struct {
 	Limit     *int
 	Namespace string
+	CallOptions
 }
 
 // LearnOptions are optional parameters for Learn.
 type LearnOptions struct {
 	Namespace string
 	Metadata  map[string]interface{}
+	CallOptions
 }
 
 // SearchOptions are optional parameters for Search.
@@ -240,17 +452,20 @@ type SearchOptions struct {
 	Namespace string
 	NodeType  *string
 	TimeRange *[2]int64
+	CallOptions
 }
 
 // AddMemoryOptions are optional parameters for AddMemory.
 type AddMemoryOptions struct {
 	Namespace string
 	Metadata  map[string]interface{}
+	CallOptions
 }
 
 // EntityOptions are optional parameters for entity operations.
 type EntityOptions struct {
 	Namespace string
+	CallOptions
 }
 
 // ListMemoriesOptions are optional parameters for ListMemories.
@@ -259,6 +474,7 @@ type ListMemoriesOptions struct {
 	Limit     *int
 	Offset    *int
 	Namespace string
+	CallOptions
 }
 
 // ListEntitiesOptions are optional parameters for ListEntities.
@@ -266,6 +482,7 @@ type ListEntitiesOptions struct {
 	Limit     *int
 	Offset    *int
 	Namespace string
+	CallOptions
 }
 
 // --- Client Methods (12 total) ---
@@ -274,12 +491,16 @@ type ListEntitiesOptions struct {
 func (c *Client) Augment(ctx context.Context, contextText string, opts *AugmentOptions) (*AugmentResponse, error) {
 	reqBody := AugmentRequest{Context: contextText}
 	namespace := ""
+	var co CallOptions
 	if opts != nil {
 		reqBody.Limit = opts.Limit
 		namespace = opts.Namespace
+		co = opts.CallOptions
 	}
+	ctx, cancel := withCallTimeout(ctx, co)
+	defer cancel()
 
-	data, err := c.doRequest(ctx, http.MethodPost, "/api/v1/augment", reqBody, namespace)
+	data, err := c.doRequest(ctx, http.MethodPost, "/api/v1/augment", reqBody, namespace, co)
 	if err != nil {
 		return nil, err
 	}
@@ -292,15 +513,24 @@ func (c *Client) Augment(ctx context.Context, contextText string, opts *AugmentO
 }
 
 // Learn extracts and stores memories from agent output text.
+//
+// Each logical call carries an Idempotency-Key header, generated once and
+// reused across retries, so the server can safely dedupe a call that
+// succeeded but whose response was lost before reaching the client: it must
+// return the original response for a replayed key rather than re-ingesting.
 func (c *Client) Learn(ctx context.Context, output string, opts *LearnOptions) (*LearnResponse, error) {
 	reqBody := LearnRequest{Output: output}
 	namespace := ""
+	var co CallOptions
 	if opts != nil {
 		reqBody.Metadata = opts.Metadata
 		namespace = opts.Namespace
+		co = opts.CallOptions
 	}
+	ctx, cancel := withCallTimeout(ctx, co)
+	defer cancel()
 
-	data, err := c.doRequest(ctx, http.MethodPost, "/api/v1/learn", reqBody, namespace)
+	data, err := c.doRequest(ctx, http.MethodPost, "/api/v1/learn", reqBody, namespace, co)
 	if err != nil {
 		return nil, err
 	}
@@ -309,6 +539,7 @@ func (c *Client) Learn(ctx context.Context, output string, opts *LearnOptions) (
 	if err := json.Unmarshal(data, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse learn response: %w", err)
 	}
+	c.observer.ObserveIngestion(result.Metrics)
 	return &result, nil
 }
 
@@ -316,14 +547,18 @@ func (c *Client) Learn(ctx context.Context, output string, opts *LearnOptions) (
 func (c *Client) Search(ctx context.Context, query string, opts *SearchOptions) (*SearchResponse, error) {
 	reqBody := SearchRequest{Query: query}
 	namespace := ""
+	var co CallOptions
 	if opts != nil {
 		reqBody.Limit = opts.Limit
 		reqBody.NodeType = opts.NodeType
 		reqBody.TimeRange = opts.TimeRange
 		namespace = opts.Namespace
+		co = opts.CallOptions
 	}
+	ctx, cancel := withCallTimeout(ctx, co)
+	defer cancel()
 
-	data, err := c.doRequest(ctx, http.MethodPost, "/api/v1/memories/search", reqBody, namespace)
+	data, err := c.doRequest(ctx, http.MethodPost, "/api/v1/memories/search", reqBody, namespace, co)
 	if err != nil {
 		return nil, err
 	}
@@ -336,15 +571,24 @@ func (c *Client) Search(ctx context.Context, query string, opts *SearchOptions)
 }
 
 // AddMemory ingests a text as a new memory.
+//
+// Each logical call carries an Idempotency-Key header, generated once and
+// reused across retries, so the server can safely dedupe a call that
+// succeeded but whose response was lost before reaching the client: it must
+// return the original response for a replayed key rather than re-ingesting.
 func (c *Client) AddMemory(ctx context.Context, text string, opts *AddMemoryOptions) (*CreateMemoryResponse, error) {
 	reqBody := CreateMemoryRequest{Text: text}
 	namespace := ""
+	var co CallOptions
 	if opts != nil {
 		reqBody.Metadata = opts.Metadata
 		namespace = opts.Namespace
+		co = opts.CallOptions
 	}
+	ctx, cancel := withCallTimeout(ctx, co)
+	defer cancel()
 
-	data, err := c.doRequest(ctx, http.MethodPost, "/api/v1/memories", reqBody, namespace)
+	data, err := c.doRequest(ctx, http.MethodPost, "/api/v1/memories", reqBody, namespace, co)
 	if err != nil {
 		return nil, err
 	}
@@ -353,17 +597,22 @@ func (c *Client) AddMemory(ctx context.Context, text string, opts *AddMemoryOpti
 	if err := json.Unmarshal(data, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse create memory response: %w", err)
 	}
+	c.observer.ObserveIngestion(result.Metrics)
 	return &result, nil
 }
 
 // GetMemory retrieves a single memory by ID.
 func (c *Client) GetMemory(ctx context.Context, id int64, opts *EntityOptions) (*MemoryResponse, error) {
 	namespace := ""
+	var co CallOptions
 	if opts != nil {
 		namespace = opts.Namespace
+		co = opts.CallOptions
 	}
+	ctx, cancel := withCallTimeout(ctx, co)
+	defer cancel()
 
-	data, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/memories/%d", id), nil, namespace)
+	data, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/memories/%d", id), nil, namespace, co)
 	if err != nil {
 		return nil, err
 	}
@@ -380,9 +629,11 @@ func (c *Client) ListMemories(ctx context.Context, opts *ListMemoriesOptions) ([
 	path := "/api/v1/memories"
 	namespace := ""
 	params := []string{}
+	var co CallOptions
 
 	if opts != nil {
 		namespace = opts.Namespace
+		co = opts.CallOptions
 		if opts.Limit != nil {
 			params = append(params, fmt.Sprintf("limit=%d", *opts.Limit))
 		}
@@ -393,12 +644,14 @@ func (c *Client) ListMemories(ctx context.Context, opts *ListMemoriesOptions) ([
 			params = append(params, fmt.Sprintf("node_type=%s", *opts.NodeType))
 		}
 	}
+	ctx, cancel := withCallTimeout(ctx, co)
+	defer cancel()
 
 	if len(params) > 0 {
 		path += "?" + strings.Join(params, "&")
 	}
 
-	data, err := c.doRequest(ctx, http.MethodGet, path, nil, namespace)
+	data, err := c.doRequest(ctx, http.MethodGet, path, nil, namespace, co)
 	if err != nil {
 		return nil, err
 	}
@@ -413,11 +666,15 @@ func (c *Client) ListMemories(ctx context.Context, opts *ListMemoriesOptions) ([
 // UpdateMemory updates a memory's content and/or metadata.
 func (c *Client) UpdateMemory(ctx context.Context, id int64, req *UpdateMemoryRequest, opts *EntityOptions) (*MemoryResponse, error) {
 	namespace := ""
+	var co CallOptions
 	if opts != nil {
 		namespace = opts.Namespace
+		co = opts.CallOptions
 	}
+	ctx, cancel := withCallTimeout(ctx, co)
+	defer cancel()
 
-	data, err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("/api/v1/memories/%d", id), req, namespace)
+	data, err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("/api/v1/memories/%d", id), req, namespace, co)
 	if err != nil {
 		return nil, err
 	}
@@ -432,22 +689,30 @@ func (c *Client) UpdateMemory(ctx context.Context, id int64, req *UpdateMemoryRe
 // DeleteMemory soft-deletes a memory by ID.
 func (c *Client) DeleteMemory(ctx context.Context, id int64, opts *EntityOptions) error {
 	namespace := ""
+	var co CallOptions
 	if opts != nil {
 		namespace = opts.Namespace
+		co = opts.CallOptions
 	}
+	ctx, cancel := withCallTimeout(ctx, co)
+	defer cancel()
 
-	_, err := c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/v1/memories/%d", id), nil, namespace)
+	_, err := c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/v1/memories/%d", id), nil, namespace, co)
 	return err
 }
 
 // GetEntity retrieves an entity by ID with its neighbors.
 func (c *Client) GetEntity(ctx context.Context, id int64, opts *EntityOptions) (*EntityResponse, error) {
 	namespace := ""
+	var co CallOptions
 	if opts != nil {
 		namespace = opts.Namespace
+		co = opts.CallOptions
 	}
+	ctx, cancel := withCallTimeout(ctx, co)
+	defer cancel()
 
-	data, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/entities/%d", id), nil, namespace)
+	data, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/entities/%d", id), nil, namespace, co)
 	if err != nil {
 		return nil, err
 	}
@@ -464,9 +729,11 @@ func (c *Client) ListEntities(ctx context.Context, opts *ListEntitiesOptions) ([
 	path := "/api/v1/entities"
 	namespace := ""
 	params := []string{}
+	var co CallOptions
 
 	if opts != nil {
 		namespace = opts.Namespace
+		co = opts.CallOptions
 		if opts.Limit != nil {
 			params = append(params, fmt.Sprintf("limit=%d", *opts.Limit))
 		}
@@ -474,12 +741,14 @@ func (c *Client) ListEntities(ctx context.Context, opts *ListEntitiesOptions) ([
 			params = append(params, fmt.Sprintf("offset=%d", *opts.Offset))
 		}
 	}
+	ctx, cancel := withCallTimeout(ctx, co)
+	defer cancel()
 
 	if len(params) > 0 {
 		path += "?" + strings.Join(params, "&")
 	}
 
-	data, err := c.doRequest(ctx, http.MethodGet, path, nil, namespace)
+	data, err := c.doRequest(ctx, http.MethodGet, path, nil, namespace, co)
 	if err != nil {
 		return nil, err
 	}
@@ -493,7 +762,7 @@ func (c *Client) ListEntities(ctx context.Context, opts *ListEntitiesOptions) ([
 
 // Health returns the server health status.
 func (c *Client) Health(ctx context.Context) (*HealthResponse, error) {
-	data, err := c.doRequest(ctx, http.MethodGet, "/api/v1/health", nil, "")
+	data, err := c.doRequest(ctx, http.MethodGet, "/api/v1/health", nil, "", CallOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -507,7 +776,7 @@ func (c *Client) Health(ctx context.Context) (*HealthResponse, error) {
 
 // Metrics returns server metrics (request counts, uptime).
 func (c *Client) Metrics(ctx context.Context) (*MetricsResponse, error) {
-	data, err := c.doRequest(ctx, http.MethodGet, "/api/v1/metrics", nil, "")
+	data, err := c.doRequest(ctx, http.MethodGet, "/api/v1/metrics", nil, "", CallOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -35,8 +35,8 @@ func TestNewClientDefaults(t *testing.T) {
 	c := NewClient("http://localhost:8420", nil)
 	defer c.Close()
 
-	if c.baseURL != "http://localhost:8420" {
-		t.Errorf("expected baseURL http://localhost:8420, got %s", c.baseURL)
+	if c.endpoint() != "http://localhost:8420" {
+		t.Errorf("expected endpoint http://localhost:8420, got %s", c.endpoint())
 	}
 	if c.defaultNamespace != "default" {
 		t.Errorf("expected default namespace 'default', got %s", c.defaultNamespace)
@@ -64,8 +64,8 @@ func TestNewClientCustomConfig(t *testing.T) {
 	})
 	defer c.Close()
 
-	if c.baseURL != "http://example.com:9999" {
-		t.Errorf("expected trailing slash stripped, got %s", c.baseURL)
+	if c.endpoint() != "http://example.com:9999" {
+		t.Errorf("expected trailing slash stripped, got %s", c.endpoint())
 	}
 	if c.defaultNamespace != "test-ns" {
 		t.Errorf("expected namespace 'test-ns', got %s", c.defaultNamespace)
@@ -264,7 +264,7 @@ func TestUcotronConnectionError(t *testing.T) {
 
 func TestUcotronRetriesExhaustedError(t *testing.T) {
 	lastErr := &UcotronServerError{StatusCode: 500, Code: "INTERNAL", Message: "oops"}
-	err := &UcotronRetriesExhaustedError{Attempts: 4, LastError: lastErr}
+	err := &UcotronRetriesExhaustedError{attempts: 4, LastError: lastErr}
 	s := err.Error()
 	if !strings.Contains(s, "4 attempts") {
 		t.Errorf("error message missing attempt count: %s", s)
@@ -452,6 +452,45 @@ func TestGetMemory(t *testing.T) {
 	}
 }
 
+func TestGetMemoryNotFoundReadsRequestIDHeader(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Ucotron-Request-ID", "req-from-header")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(APIErrorBody{Code: "NOT_FOUND", Message: "no such memory"})
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	_, err := c.GetMemory(context.Background(), 42, nil)
+
+	var serverErr *UcotronServerError
+	if !errors.As(err, &serverErr) {
+		t.Fatalf("expected a *UcotronServerError, got %T: %v", err, err)
+	}
+	if serverErr.RequestID() != "req-from-header" {
+		t.Errorf("expected RequestID() to read the response header on a real client call, got %q", serverErr.RequestID())
+	}
+}
+
+func TestGetMemoryNotFoundIsClassified(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(APIErrorBody{Code: "NOT_FOUND", Message: "no such memory"})
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	_, err := c.GetMemory(context.Background(), 42, nil)
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected errors.Is(err, ErrNotFound) on a real GetMemory 404, got %T: %v", err, err)
+	}
+	var serverErr *UcotronServerError
+	if !errors.As(err, &serverErr) {
+		t.Error("expected errors.As to still reach the underlying *UcotronServerError")
+	}
+}
+
 func TestListMemories(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !strings.HasPrefix(r.URL.Path, "/api/v1/memories") {
@@ -678,8 +717,8 @@ func TestRetriesExhausted(t *testing.T) {
 	if !errors.As(err, &retriesErr) {
 		t.Fatalf("expected UcotronRetriesExhaustedError, got %T: %v", err, err)
 	}
-	if retriesErr.Attempts != 3 {
-		t.Errorf("expected 3 attempts, got %d", retriesErr.Attempts)
+	if retriesErr.Attempts() != 3 {
+		t.Errorf("expected 3 attempts, got %d", retriesErr.Attempts())
 	}
 	// MaxRetries=2 means 3 total attempts (1 initial + 2 retries)
 	if atomic.LoadInt32(&callCount) != 3 {
@@ -729,6 +768,13 @@ func TestContextCancellation(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error from cancelled context")
 	}
+	var canceledErr *UcotronCanceledError
+	if !errors.As(err, &canceledErr) {
+		t.Fatalf("expected a *UcotronCanceledError, got %T: %v", err, err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("expected errors.Is(err, context.DeadlineExceeded) to hold through UcotronCanceledError")
+	}
 }
 
 // --- Content-Type Header Test ---
@@ -0,0 +1,64 @@
+package ucotron
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ClusterMembersResponse is the response body for GET /api/v1/cluster/members.
+type ClusterMembersResponse struct {
+	Endpoints []string `json:"endpoints"`
+}
+
+// Endpoints returns the client's current cluster endpoint list, in rotation
+// order starting from the pinned endpoint.
+func (c *Client) Endpoints() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]string, len(c.endpoints))
+	for i := range c.endpoints {
+		out[i] = c.endpoints[(c.pinnedIdx+i)%len(c.endpoints)]
+	}
+	return out
+}
+
+// SyncEndpoints refreshes the client's endpoint list by querying
+// /api/v1/cluster/members on the pinned endpoint, discovering peers the
+// client wasn't originally configured with. It keeps the currently pinned
+// endpoint pinned if it's still present in the refreshed list, and
+// otherwise pins the first discovered endpoint.
+func (c *Client) SyncEndpoints(ctx context.Context) error {
+	data, err := c.doRequest(ctx, http.MethodGet, "/api/v1/cluster/members", nil, "", CallOptions{})
+	if err != nil {
+		return err
+	}
+
+	var result ClusterMembersResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("failed to parse cluster members response: %w", err)
+	}
+	if len(result.Endpoints) == 0 {
+		return nil
+	}
+
+	endpoints := make([]string, len(result.Endpoints))
+	for i, e := range result.Endpoints {
+		endpoints[i] = strings.TrimRight(e, "/")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	pinned := c.endpoints[c.pinnedIdx]
+	c.endpoints = endpoints
+	c.pinnedIdx = 0
+	for i, e := range endpoints {
+		if e == pinned {
+			c.pinnedIdx = i
+			break
+		}
+	}
+	return nil
+}
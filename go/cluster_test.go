@@ -0,0 +1,89 @@
+package ucotron
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClusterFailsOverToHealthyEndpoint(t *testing.T) {
+	var gotNamespace string
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotNamespace = r.Header.Get(namespaceHeader)
+		w.WriteHeader(200)
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer healthy.Close()
+
+	dead := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	dead.Close() // connections to this address now fail immediately
+
+	c := NewClient(dead.URL, &ClientConfig{
+		Endpoints: []string{healthy.URL},
+		Retry:     &RetryConfig{MaxRetries: 2, BaseDelayMs: 1, MaxDelayMs: 1},
+	})
+	defer c.Close()
+
+	if _, err := c.Health(context.Background()); err != nil {
+		t.Fatalf("expected failover to the healthy endpoint, got %v", err)
+	}
+	if gotNamespace != "default" {
+		t.Errorf("expected request to reach the healthy endpoint, got namespace %q", gotNamespace)
+	}
+}
+
+func TestClusterAllDeadReturnsRetriesExhaustedWithEndpointErrors(t *testing.T) {
+	deadA := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	deadA.Close()
+	deadB := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	deadB.Close()
+
+	c := NewClient(deadA.URL, &ClientConfig{
+		Endpoints: []string{deadB.URL},
+		Retry:     &RetryConfig{MaxRetries: 1, BaseDelayMs: 1, MaxDelayMs: 1},
+	})
+	defer c.Close()
+
+	_, err := c.Health(context.Background())
+	var retriesErr *UcotronRetriesExhaustedError
+	if !errors.As(err, &retriesErr) {
+		t.Fatalf("expected UcotronRetriesExhaustedError, got %T: %v", err, err)
+	}
+	if len(retriesErr.EndpointErrors) != 2 {
+		t.Fatalf("expected a recorded error for each of the 2 endpoints, got %v", retriesErr.EndpointErrors)
+	}
+	if retriesErr.EndpointErrors[deadA.URL] == nil || retriesErr.EndpointErrors[deadB.URL] == nil {
+		t.Errorf("expected both dead endpoints to have a recorded error, got %v", retriesErr.EndpointErrors)
+	}
+}
+
+func TestClusterStickySelectionAfterSuccess(t *testing.T) {
+	var hitsA, hitsB int
+	serverA := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hitsA++
+		w.WriteHeader(200)
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer serverA.Close()
+	serverB := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hitsB++
+		w.WriteHeader(200)
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer serverB.Close()
+
+	c := NewClient(serverA.URL, &ClientConfig{Endpoints: []string{serverB.URL}})
+	defer c.Close()
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.Health(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if hitsA != 3 || hitsB != 0 {
+		t.Errorf("expected all 3 calls to stick to the first endpoint, got hitsA=%d hitsB=%d", hitsA, hitsB)
+	}
+}
@@ -1,18 +1,180 @@
 package ucotron
 
-import "fmt"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// requestIDHeader is the response header servers use to report a
+// request-scoped identifier for correlating error reports with server logs.
+const requestIDHeader = "X-Ucotron-Request-ID"
 
 // UcotronServerError represents a 4xx/5xx HTTP error from the server.
 type UcotronServerError struct {
 	StatusCode int
 	Code       string
 	Message    string
+
+	// requestID and data are populated by ParseServerError from the
+	// X-Ucotron-Request-ID response header (or the body's request_id field)
+	// and the error body's data field, respectively. Use RequestID and
+	// ErrorData to read them.
+	requestID string
+	data      any
 }
 
 func (e *UcotronServerError) Error() string {
+	if e.requestID != "" {
+		return fmt.Sprintf("ucotron server error %d (%s): %s [request_id=%s]", e.StatusCode, e.Code, e.Message, e.requestID)
+	}
 	return fmt.Sprintf("ucotron server error %d (%s): %s", e.StatusCode, e.Code, e.Message)
 }
 
+// RequestID returns the server-assigned identifier for the request that
+// produced this error, or "" if the server didn't report one.
+func (e *UcotronServerError) RequestID() string {
+	return e.requestID
+}
+
+// ErrorData returns the structured "data" field from the server's error
+// body, or nil if it didn't send one.
+func (e *UcotronServerError) ErrorData() any {
+	return e.data
+}
+
+// parseErrorBody decodes a JSON error envelope ({code, message, data,
+// request_id}) off body. ok is false if body isn't a recognizable error
+// envelope (not JSON, or missing a message), in which case the caller
+// should fall back to treating body as plain text.
+func parseErrorBody(body []byte) (code, message string, data any, requestID string, ok bool) {
+	var apiErr APIErrorBody
+	if err := json.Unmarshal(body, &apiErr); err != nil || apiErr.Message == "" {
+		return "", "", nil, "", false
+	}
+	if len(apiErr.Data) > 0 {
+		json.Unmarshal(apiErr.Data, &data)
+	}
+	return apiErr.Code, apiErr.Message, data, apiErr.RequestID, true
+}
+
+// ParseServerError reads and closes resp.Body, decoding it into a
+// UcotronServerError. The request ID is taken from the
+// X-Ucotron-Request-ID response header when present, otherwise from the
+// body's own request_id field. A body that isn't a valid error envelope is
+// preserved as the error's Message verbatim, with Code set to
+// "BadErrorResponse" so callers can distinguish a malformed response from a
+// well-formed one.
+func ParseServerError(resp *http.Response) *UcotronServerError {
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	se := &UcotronServerError{
+		StatusCode: resp.StatusCode,
+		Code:       "BadErrorResponse",
+		Message:    string(body),
+	}
+	if code, message, data, requestID, ok := parseErrorBody(body); ok {
+		se.Code = code
+		se.Message = message
+		se.data = data
+		se.requestID = requestID
+	}
+	if h := resp.Header.Get(requestIDHeader); h != "" {
+		se.requestID = h
+	}
+	return se
+}
+
+// Sentinel errors for well-known server error classes. ClassifyStatus maps
+// an HTTP status code (or, when it names one of these classes, the server's
+// error code) to one of these, so callers can write errors.Is(err, ucotron.ErrNotFound)
+// instead of pattern-matching on (*UcotronServerError).StatusCode.
+var (
+	ErrAccessDenied       = fmt.Errorf("ucotron: access denied")
+	ErrNotFound           = fmt.Errorf("ucotron: not found")
+	ErrPreconditionFailed = fmt.Errorf("ucotron: precondition failed")
+	ErrRateLimited        = fmt.Errorf("ucotron: rate limited")
+	ErrInvalidParameters  = fmt.Errorf("ucotron: invalid parameters")
+	ErrInternal           = fmt.Errorf("ucotron: internal server error")
+	ErrBadGateway         = fmt.Errorf("ucotron: bad gateway")
+	ErrServiceUnavailable = fmt.Errorf("ucotron: service unavailable")
+)
+
+// classifiedError pairs a sentinel from the list above with the
+// *UcotronServerError it was classified from, so errors.Is matches the
+// sentinel while errors.As/Unwrap still reaches the full server error.
+type classifiedError struct {
+	sentinel error
+	server   *UcotronServerError
+}
+
+func (e *classifiedError) Error() string { return e.server.Error() }
+
+func (e *classifiedError) Is(target error) bool { return target == e.sentinel }
+
+func (e *classifiedError) Unwrap() error { return e.server }
+
+// ClassifyStatus parses statusCode/body into a *UcotronServerError (see
+// parseServerError) and wraps it in the sentinel error class it belongs to,
+// preferring the server's own error code when it names one of the classes
+// below and falling back to the status code otherwise.
+func ClassifyStatus(statusCode int, body []byte) error {
+	return classify(parseServerError(statusCode, body))
+}
+
+// classify wraps an already-parsed *UcotronServerError in the sentinel error
+// class it belongs to. Client methods call this directly on the
+// *UcotronServerError they already built (see doRequest), rather than going
+// through ClassifyStatus and re-parsing the body they've already parsed.
+func classify(server *UcotronServerError) error {
+	return &classifiedError{sentinel: sentinelFor(server.StatusCode, server.Code), server: server}
+}
+
+// sentinelFor picks the sentinel error for a status code/server error code
+// pair. Codes that match a well-known class name win over the raw status
+// code, since servers sometimes reuse one status code (e.g. 400) across
+// several of these classes.
+func sentinelFor(statusCode int, code string) error {
+	switch code {
+	case "ACCESS_DENIED", "PERMISSION_DENIED", "FORBIDDEN":
+		return ErrAccessDenied
+	case "NOT_FOUND":
+		return ErrNotFound
+	case "PRECONDITION_FAILED", "FAILED_PRECONDITION":
+		return ErrPreconditionFailed
+	case "RATE_LIMITED", "TOO_MANY_REQUESTS":
+		return ErrRateLimited
+	case "INVALID_PARAMETERS", "INVALID_ARGUMENT", "BAD_REQUEST":
+		return ErrInvalidParameters
+	}
+
+	switch statusCode {
+	case http.StatusForbidden:
+		return ErrAccessDenied
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusPreconditionFailed:
+		return ErrPreconditionFailed
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusBadGateway:
+		return ErrBadGateway
+	case http.StatusServiceUnavailable:
+		return ErrServiceUnavailable
+	case http.StatusInternalServerError:
+		return ErrInternal
+	default:
+		if statusCode >= 400 && statusCode < 500 {
+			return ErrInvalidParameters
+		}
+		return ErrInternal
+	}
+}
+
 // UcotronConnectionError represents a network/connection failure.
 type UcotronConnectionError struct {
 	Message string
@@ -30,16 +192,72 @@ func (e *UcotronConnectionError) Unwrap() error {
 	return e.Cause
 }
 
+// UcotronCanceledError is returned when an in-flight request terminates
+// because the caller's context.Context was canceled or its deadline
+// expired, as distinct from UcotronConnectionError (a network-level
+// failure the caller didn't ask for). It is never retried. Unwrap reaches
+// the underlying context.Canceled/context.DeadlineExceeded, so
+// errors.Is(err, context.DeadlineExceeded) still works.
+type UcotronCanceledError struct {
+	Cause error
+}
+
+func (e *UcotronCanceledError) Error() string {
+	return fmt.Sprintf("ucotron request canceled: %v", e.Cause)
+}
+
+func (e *UcotronCanceledError) Unwrap() error {
+	return e.Cause
+}
+
+// wrapIfCtxErr wraps err in a UcotronCanceledError if it is (or wraps)
+// context.Canceled or context.DeadlineExceeded, leaving any other error —
+// including a custom RateLimiter's own errors — untouched.
+func wrapIfCtxErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return &UcotronCanceledError{Cause: err}
+	}
+	return err
+}
+
 // UcotronRetriesExhaustedError is returned when all retry attempts fail.
 type UcotronRetriesExhaustedError struct {
-	Attempts  int
 	LastError error
+	// EndpointErrors records the last error seen from each endpoint that was
+	// tried, keyed by endpoint URL. For a single-endpoint client it holds
+	// that endpoint's last error.
+	EndpointErrors map[string]error
+
+	attempts int
+	// errs holds one error per attempt, in order; errs[len(errs)-1] is the
+	// same error as LastError.
+	errs []error
 }
 
 func (e *UcotronRetriesExhaustedError) Error() string {
-	return fmt.Sprintf("ucotron retries exhausted after %d attempts: %v", e.Attempts, e.LastError)
+	return fmt.Sprintf("ucotron retries exhausted after %d attempts: %v", e.attempts, e.LastError)
+}
+
+// Attempts returns the number of attempts made before giving up.
+func (e *UcotronRetriesExhaustedError) Attempts() int {
+	return e.attempts
 }
 
-func (e *UcotronRetriesExhaustedError) Unwrap() error {
-	return e.LastError
+// Errors returns the error from every attempt, in the order they occurred.
+func (e *UcotronRetriesExhaustedError) Errors() []error {
+	return e.errs
+}
+
+// Unwrap exposes every attempt's error for errors.Is/As traversal (Go 1.20+
+// multi-error unwrapping), so callers can match against any attempt along
+// the way, not just the last one. If errs wasn't populated (e.g. a value
+// built by hand with only LastError set), it falls back to that.
+func (e *UcotronRetriesExhaustedError) Unwrap() []error {
+	if len(e.errs) == 0 && e.LastError != nil {
+		return []error{e.LastError}
+	}
+	return e.errs
 }
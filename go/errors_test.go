@@ -0,0 +1,154 @@
+package ucotron
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClassifyStatusByStatusCode(t *testing.T) {
+	cases := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusForbidden, ErrAccessDenied},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusPreconditionFailed, ErrPreconditionFailed},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusBadGateway, ErrBadGateway},
+		{http.StatusServiceUnavailable, ErrServiceUnavailable},
+		{http.StatusInternalServerError, ErrInternal},
+		{http.StatusBadRequest, ErrInvalidParameters},
+	}
+	for _, tc := range cases {
+		err := ClassifyStatus(tc.status, []byte(`{"code":"x","message":"boom"}`))
+		if !errors.Is(err, tc.want) {
+			t.Errorf("status %d: expected errors.Is to match %v, got %v", tc.status, tc.want, err)
+		}
+	}
+}
+
+func TestClassifyStatusByServerCodeOverridesStatus(t *testing.T) {
+	// A 400 that names NOT_FOUND in its error code should still classify as
+	// ErrNotFound, not the generic ErrInvalidParameters a bare 400 implies.
+	err := ClassifyStatus(http.StatusBadRequest, []byte(`{"code":"NOT_FOUND","message":"no such memory"}`))
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected the server's NOT_FOUND code to win, got %v", err)
+	}
+}
+
+func TestParseServerErrorReadsRequestIDHeader(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Ucotron-Request-ID", "req-123")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":"NOT_FOUND","message":"no such memory"}`))
+	}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serverErr := ParseServerError(resp)
+	if serverErr.RequestID() != "req-123" {
+		t.Errorf("expected RequestID() to read the header, got %q", serverErr.RequestID())
+	}
+	if serverErr.Error() == "" || serverErr.StatusCode != http.StatusNotFound {
+		t.Errorf("unexpected parsed error: %+v", serverErr)
+	}
+}
+
+func TestParseServerErrorFallsBackToRequestIDInBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"code":"INTERNAL","message":"boom","request_id":"req-from-body"}`))
+	}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serverErr := ParseServerError(resp)
+	if serverErr.RequestID() != "req-from-body" {
+		t.Errorf("expected RequestID() to fall back to the body field, got %q", serverErr.RequestID())
+	}
+}
+
+func TestParseServerErrorDecodesData(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		w.Write([]byte(`{"code":"PRECONDITION_FAILED","message":"version mismatch","data":{"expected_version":3}}`))
+	}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serverErr := ParseServerError(resp)
+	data, ok := serverErr.ErrorData().(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected ErrorData() to decode the data object, got %T", serverErr.ErrorData())
+	}
+	if data["expected_version"] != float64(3) {
+		t.Errorf("unexpected data: %+v", data)
+	}
+}
+
+func TestParseServerErrorNonJSONBodyIsBadErrorResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("upstream is on fire"))
+	}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serverErr := ParseServerError(resp)
+	if serverErr.Code != "BadErrorResponse" || serverErr.Message != "upstream is on fire" {
+		t.Errorf("expected a BadErrorResponse with the raw body, got %+v", serverErr)
+	}
+}
+
+func TestWrapIfCtxErrWrapsContextErrors(t *testing.T) {
+	for _, cause := range []error{context.Canceled, context.DeadlineExceeded} {
+		err := wrapIfCtxErr(cause)
+		var canceledErr *UcotronCanceledError
+		if !errors.As(err, &canceledErr) {
+			t.Errorf("expected %v to be wrapped in a *UcotronCanceledError, got %T", cause, err)
+		}
+		if !errors.Is(err, cause) {
+			t.Errorf("expected errors.Is(err, %v) to hold", cause)
+		}
+	}
+}
+
+func TestWrapIfCtxErrLeavesOtherErrorsUntouched(t *testing.T) {
+	other := errors.New("some other failure")
+	if wrapIfCtxErr(other) != other {
+		t.Error("expected a non-context error to pass through unchanged")
+	}
+	if wrapIfCtxErr(nil) != nil {
+		t.Error("expected nil to pass through unchanged")
+	}
+}
+
+func TestClassifyStatusUnwrapsToServerError(t *testing.T) {
+	err := ClassifyStatus(http.StatusNotFound, []byte(`{"code":"NOT_FOUND","message":"no such memory"}`))
+	var serverErr *UcotronServerError
+	if !errors.As(err, &serverErr) {
+		t.Fatal("expected errors.As to reach the underlying *UcotronServerError")
+	}
+	if serverErr.StatusCode != http.StatusNotFound || serverErr.Message != "no such memory" {
+		t.Errorf("unexpected underlying server error: %+v", serverErr)
+	}
+}
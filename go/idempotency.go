@@ -0,0 +1,37 @@
+package ucotron
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyKeyFor returns the Idempotency-Key to send for a request of the
+// given method, or "" if none should be sent. GET requests never get one;
+// other methods get co.IdempotencyKey if set, otherwise a freshly generated
+// UUIDv4, unless co.NonIdempotent opts out.
+func idempotencyKeyFor(method string, co CallOptions) string {
+	if method == http.MethodGet || co.NonIdempotent {
+		return ""
+	}
+	if co.IdempotencyKey != "" {
+		return co.IdempotencyKey
+	}
+	return newUUIDv4()
+}
+
+// newUUIDv4 generates a random RFC 4122 version 4 UUID.
+func newUUIDv4() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand.Read on the standard library's Reader does not fail
+		// in practice; fall back to an all-zero UUID rather than panicking.
+		return "00000000-0000-4000-8000-000000000000"
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
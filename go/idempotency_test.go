@@ -0,0 +1,92 @@
+package ucotron
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestNewUUIDv4Format(t *testing.T) {
+	id := newUUIDv4()
+	if len(id) != 36 {
+		t.Fatalf("expected 36-char UUID, got %q (%d chars)", id, len(id))
+	}
+	if id[14] != '4' {
+		t.Errorf("expected version nibble '4', got %q", id)
+	}
+}
+
+func TestIdempotencyKeyForGetIsEmpty(t *testing.T) {
+	if key := idempotencyKeyFor(http.MethodGet, CallOptions{}); key != "" {
+		t.Errorf("expected no idempotency key for GET, got %q", key)
+	}
+}
+
+func TestIdempotencyKeyForNonIdempotentIsEmpty(t *testing.T) {
+	key := idempotencyKeyFor(http.MethodPost, CallOptions{NonIdempotent: true})
+	if key != "" {
+		t.Errorf("expected no idempotency key when NonIdempotent is set, got %q", key)
+	}
+}
+
+func TestIdempotencyKeyForUsesOverride(t *testing.T) {
+	key := idempotencyKeyFor(http.MethodPost, CallOptions{IdempotencyKey: "custom-key"})
+	if key != "custom-key" {
+		t.Errorf("expected override key, got %q", key)
+	}
+}
+
+func TestIdempotencyKeyReusedAcrossRetries(t *testing.T) {
+	var keys []string
+	var callCount int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if atomic.AddInt32(&callCount, 1) <= 2 {
+			w.WriteHeader(500)
+			return
+		}
+		w.WriteHeader(201)
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, &ClientConfig{
+		Retry: &RetryConfig{MaxRetries: 3, BaseDelayMs: 1, MaxDelayMs: 1},
+	})
+	defer c.Close()
+
+	if _, err := c.AddMemory(context.Background(), "text", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(keys) != 3 {
+		t.Fatalf("expected 3 attempts, got %d", len(keys))
+	}
+	if keys[0] == "" || keys[0] != keys[1] || keys[1] != keys[2] {
+		t.Errorf("expected the same idempotency key across retries, got %v", keys)
+	}
+}
+
+func TestIdempotencyKeyOverrideViaCallOptions(t *testing.T) {
+	var seen string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r.Header.Get("Idempotency-Key")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	defer c.Close()
+
+	_, err := c.AddMemory(context.Background(), "text", &AddMemoryOptions{
+		CallOptions: WithIdempotencyKey("event-42"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seen != "event-42" {
+		t.Errorf("expected caller-supplied idempotency key, got %q", seen)
+	}
+}
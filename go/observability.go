@@ -0,0 +1,28 @@
+package ucotron
+
+import "time"
+
+// Observer receives instrumentation events from a Client so that callers can
+// plug in Prometheus, OpenTelemetry, or any other metrics backend without
+// wrapping the HTTP transport. All methods are called synchronously from the
+// request path, so implementations must be cheap and non-blocking.
+type Observer interface {
+	// ObserveRequest is called once per HTTP attempt (including retries),
+	// after the attempt has completed, whether it succeeded or failed.
+	// status is "error" when the attempt never produced an HTTP response.
+	ObserveRequest(method, path, status string, dur time.Duration)
+	// ObserveRetry is called each time doRequest decides to retry, with the
+	// 0-based attempt number that just failed and the error that triggered
+	// the retry.
+	ObserveRetry(attempt int, err error)
+	// ObserveIngestion is called after AddMemory or Learn successfully
+	// decodes an IngestionMetrics payload.
+	ObserveIngestion(m IngestionMetrics)
+}
+
+// noopObserver is the default Observer used when ClientConfig.Observer is nil.
+type noopObserver struct{}
+
+func (noopObserver) ObserveRequest(method, path, status string, dur time.Duration) {}
+func (noopObserver) ObserveRetry(attempt int, err error)                           {}
+func (noopObserver) ObserveIngestion(m IngestionMetrics)                           {}
@@ -0,0 +1,86 @@
+package ucotron
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type recordingObserver struct {
+	mu         sync.Mutex
+	requests   []string
+	retries    int32
+	ingestions []IngestionMetrics
+}
+
+func (o *recordingObserver) ObserveRequest(method, path, status string, dur time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.requests = append(o.requests, method+" "+path+" "+status)
+}
+
+func (o *recordingObserver) ObserveRetry(attempt int, err error) {
+	atomic.AddInt32(&o.retries, 1)
+}
+
+func (o *recordingObserver) ObserveIngestion(m IngestionMetrics) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.ingestions = append(o.ingestions, m)
+}
+
+func TestObserverReceivesRequestsAndRetries(t *testing.T) {
+	var callCount int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := atomic.AddInt32(&callCount, 1)
+		if count == 1 {
+			w.WriteHeader(500)
+			return
+		}
+		w.WriteHeader(200)
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer srv.Close()
+
+	obs := &recordingObserver{}
+	c := NewClient(srv.URL, &ClientConfig{
+		Observer: obs,
+		Retry:    &RetryConfig{MaxRetries: 2, BaseDelayMs: 1, MaxDelayMs: 1},
+	})
+	defer c.Close()
+
+	if _, err := c.Health(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(obs.requests) != 2 {
+		t.Errorf("expected 2 observed requests, got %d: %v", len(obs.requests), obs.requests)
+	}
+	if atomic.LoadInt32(&obs.retries) != 1 {
+		t.Errorf("expected 1 observed retry, got %d", obs.retries)
+	}
+}
+
+func TestObserverReceivesIngestionMetrics(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(201)
+		w.Write([]byte(`{"chunk_node_ids":[1],"entity_node_ids":[2],"edges_created":1,"metrics":{"entities_extracted":3}}`))
+	}))
+	defer srv.Close()
+
+	obs := &recordingObserver{}
+	c := NewClient(srv.URL, &ClientConfig{Observer: obs})
+	defer c.Close()
+
+	if _, err := c.AddMemory(context.Background(), "text", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(obs.ingestions) != 1 || obs.ingestions[0].EntitiesExtracted != 3 {
+		t.Errorf("expected ingestion metrics with 3 entities extracted, got %+v", obs.ingestions)
+	}
+}
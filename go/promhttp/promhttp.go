@@ -0,0 +1,79 @@
+// Package promhttp provides a ready-made ucotron.Observer that registers
+// standard Prometheus histograms and counters for a Ucotron client, mirroring
+// the style of the Prometheus Go client's HTTP round-tripper instrumentation.
+package promhttp
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ucotron/sdks/go"
+)
+
+// Observer is a ucotron.Observer backed by Prometheus metrics.
+type Observer struct {
+	requestDuration    *prometheus.HistogramVec
+	retriesTotal       prometheus.Counter
+	ingestionEntities  prometheus.Counter
+	ingestionRelations prometheus.Counter
+}
+
+// NewObserver creates an Observer and registers its metrics into reg.
+func NewObserver(reg prometheus.Registerer) *Observer {
+	o := &Observer{
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "ucotron_request_duration_seconds",
+			Help:    "Duration of Ucotron client HTTP requests, by method, path, and status.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "path", "status"}),
+		retriesTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "ucotron_retries_total",
+			Help: "Total number of Ucotron client request retries.",
+		}),
+		ingestionEntities: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "ucotron_ingestion_entities_extracted",
+			Help: "Total number of entities extracted during ingestion (AddMemory/Learn).",
+		}),
+		ingestionRelations: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "ucotron_ingestion_relations_extracted",
+			Help: "Total number of relations extracted during ingestion (AddMemory/Learn).",
+		}),
+	}
+
+	reg.MustRegister(o.requestDuration, o.retriesTotal, o.ingestionEntities, o.ingestionRelations)
+	return o
+}
+
+// pathSegmentID matches a numeric path segment (a resource ID).
+var pathSegmentID = regexp.MustCompile(`/\d+(/|$)`)
+
+// routeTemplate collapses path into a bounded-cardinality route template
+// suitable for use as a Prometheus label: any query string is dropped and
+// numeric path segments (e.g. memory/entity IDs) are replaced with ":id",
+// mirroring how the Prometheus Go client's HTTP round-tripper
+// instrumentation labels by route rather than raw request path.
+func routeTemplate(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	return pathSegmentID.ReplaceAllString(path, "/:id$1")
+}
+
+// ObserveRequest implements ucotron.Observer.
+func (o *Observer) ObserveRequest(method, path, status string, dur time.Duration) {
+	o.requestDuration.WithLabelValues(method, routeTemplate(path), status).Observe(dur.Seconds())
+}
+
+// ObserveRetry implements ucotron.Observer.
+func (o *Observer) ObserveRetry(attempt int, err error) {
+	o.retriesTotal.Inc()
+}
+
+// ObserveIngestion implements ucotron.Observer.
+func (o *Observer) ObserveIngestion(m ucotron.IngestionMetrics) {
+	o.ingestionEntities.Add(float64(m.EntitiesExtracted))
+	o.ingestionRelations.Add(float64(m.RelationsExtracted))
+}
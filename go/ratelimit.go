@@ -0,0 +1,204 @@
+package ucotron
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RateLimiter throttles outgoing requests. Wait blocks until the caller may
+// proceed or ctx is done, whichever comes first, and must respect
+// ctx.Deadline() / cancellation rather than sleeping past it.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// TokenBucketLimiter is the default RateLimiter: tokens accumulate at
+// RatePerSec up to a maximum of Burst, and Wait blocks until one is
+// available. The zero value blocks forever; set RatePerSec and Burst
+// before use.
+type TokenBucketLimiter struct {
+	RatePerSec float64
+	Burst      int
+
+	mu       sync.Mutex
+	tokens   float64
+	lastTime time.Time
+}
+
+// Wait implements RateLimiter.
+func (l *TokenBucketLimiter) Wait(ctx context.Context) error {
+	for {
+		wait, ok := l.reserve()
+		if ok {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+			continue
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// reserve attempts to take a token immediately, returning (0, true) on
+// success. On failure it returns the time to wait before the next token is
+// available; the caller is expected to wait that long and try again, since
+// another waiter may take it first.
+func (l *TokenBucketLimiter) reserve() (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if l.lastTime.IsZero() {
+		l.tokens = float64(l.Burst)
+	} else if elapsed := now.Sub(l.lastTime).Seconds(); elapsed > 0 {
+		l.tokens += elapsed * l.RatePerSec
+		if l.tokens > float64(l.Burst) {
+			l.tokens = float64(l.Burst)
+		}
+	}
+	l.lastTime = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0, true
+	}
+	if l.RatePerSec <= 0 {
+		return time.Second, false
+	}
+	return time.Duration((1 - l.tokens) / l.RatePerSec * float64(time.Second)), false
+}
+
+// queueWaitBucketBounds are the upper bounds (inclusive) of each
+// ClientStats queue-wait bucket, in ascending order. An observation past
+// the last bound falls into a final, unbounded bucket.
+var queueWaitBucketBounds = []time.Duration{
+	1 * time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	1 * time.Second,
+	5 * time.Second,
+}
+
+// throttledThreshold is the minimum measured queue wait counted as
+// "throttled" rather than dispatched immediately.
+const throttledThreshold = 1 * time.Millisecond
+
+// clientStats accumulates the counters backing Client.ClientStats.
+type clientStats struct {
+	throttled        int64
+	inFlight         int64
+	queueWaitBuckets []int64 // len(queueWaitBucketBounds)+1, last is unbounded
+}
+
+func newClientStats() *clientStats {
+	return &clientStats{queueWaitBuckets: make([]int64, len(queueWaitBucketBounds)+1)}
+}
+
+func (s *clientStats) observeQueueWait(d time.Duration) {
+	if d >= throttledThreshold {
+		atomic.AddInt64(&s.throttled, 1)
+	}
+	for i, bound := range queueWaitBucketBounds {
+		if d <= bound {
+			atomic.AddInt64(&s.queueWaitBuckets[i], 1)
+			return
+		}
+	}
+	atomic.AddInt64(&s.queueWaitBuckets[len(queueWaitBucketBounds)], 1)
+}
+
+// QueueWaitBucket is one bucket of Client.ClientStats' queue-wait
+// histogram: the count of observed waits that fell at or below UpperBound
+// (or, for the final bucket, above every bound). Each in-flight-semaphore
+// acquisition (when MaxInFlight is set) and each rate-limiter wait (when a
+// RateLimiter is set, once per try including retries) is observed as a
+// separate wait; the waits of one request are not combined.
+type QueueWaitBucket struct {
+	UpperBound   time.Duration
+	NoUpperBound bool
+	Count        int64
+}
+
+// ClientStatsSnapshot is a point-in-time snapshot of a Client's rate
+// limiting and concurrency metrics, returned by Client.ClientStats.
+type ClientStatsSnapshot struct {
+	// Throttled is the number of observed waits on the rate limiter or the
+	// MaxInFlight semaphore that lasted at least 1ms; a request that waited
+	// on both, or on each of several tries, is counted once per such wait.
+	Throttled int64
+	// InFlight is the number of requests currently dispatched to the
+	// server (including retries in progress).
+	InFlight int64
+	// QueueWait buckets every observed wait by duration (see QueueWaitBucket).
+	QueueWait []QueueWaitBucket
+}
+
+// ClientStats returns a snapshot of the client's rate limiting and
+// concurrency metrics.
+func (c *Client) ClientStats() ClientStatsSnapshot {
+	buckets := make([]QueueWaitBucket, len(queueWaitBucketBounds)+1)
+	for i, bound := range queueWaitBucketBounds {
+		buckets[i] = QueueWaitBucket{UpperBound: bound, Count: atomic.LoadInt64(&c.stats.queueWaitBuckets[i])}
+	}
+	buckets[len(queueWaitBucketBounds)] = QueueWaitBucket{
+		NoUpperBound: true,
+		Count:        atomic.LoadInt64(&c.stats.queueWaitBuckets[len(queueWaitBucketBounds)]),
+	}
+
+	return ClientStatsSnapshot{
+		Throttled: atomic.LoadInt64(&c.stats.throttled),
+		InFlight:  atomic.LoadInt64(&c.stats.inFlight),
+		QueueWait: buckets,
+	}
+}
+
+// acquireInFlight reserves one of the client's MaxInFlight slots, blocking
+// until one is free or ctx is done. It returns a release func the caller
+// must invoke exactly once, typically via defer. If MaxInFlight is
+// unbounded, it only maintains the InFlight gauge.
+func (c *Client) acquireInFlight(ctx context.Context) (func(), error) {
+	if c.inFlightSem == nil {
+		atomic.AddInt64(&c.stats.inFlight, 1)
+		return func() { atomic.AddInt64(&c.stats.inFlight, -1) }, nil
+	}
+
+	start := time.Now()
+	select {
+	case c.inFlightSem <- struct{}{}:
+		c.stats.observeQueueWait(time.Since(start))
+		atomic.AddInt64(&c.stats.inFlight, 1)
+		return func() {
+			atomic.AddInt64(&c.stats.inFlight, -1)
+			<-c.inFlightSem
+		}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// waitForRateLimit blocks on the client's RateLimiter, if configured,
+// recording the wait in ClientStats. It is a no-op if no RateLimiter is
+// configured.
+func (c *Client) waitForRateLimit(ctx context.Context) error {
+	if c.rateLimiter == nil {
+		return nil
+	}
+	start := time.Now()
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return err
+	}
+	c.stats.observeQueueWait(time.Since(start))
+	return nil
+}
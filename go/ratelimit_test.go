@@ -0,0 +1,184 @@
+package ucotron
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketLimiterAllowsBurstThenThrottles(t *testing.T) {
+	l := &TokenBucketLimiter{RatePerSec: 1000, Burst: 3}
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("unexpected error within burst: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Errorf("expected the burst to be granted immediately, took %v", elapsed)
+	}
+
+	start = time.Now()
+	if err := l.Wait(ctx); err != nil {
+		t.Fatalf("unexpected error waiting for a refill: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 500*time.Microsecond {
+		t.Errorf("expected the 4th token to require waiting for a refill, took %v", elapsed)
+	}
+}
+
+func TestTokenBucketLimiterRespectsContextCancellation(t *testing.T) {
+	l := &TokenBucketLimiter{RatePerSec: 0.001, Burst: 1}
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error consuming the initial burst: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := l.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestClientStatsReportsInFlightAndThrottled(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var concurrent, maxConcurrent int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		concurrent++
+		if concurrent > maxConcurrent {
+			maxConcurrent = concurrent
+		}
+		mu.Unlock()
+
+		<-release
+
+		mu.Lock()
+		concurrent--
+		mu.Unlock()
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, &ClientConfig{MaxInFlight: 1})
+	defer c.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Health(context.Background())
+		}()
+	}
+
+	// Give both goroutines a chance to reach the server/semaphore.
+	time.Sleep(50 * time.Millisecond)
+	if stats := c.ClientStats(); stats.InFlight != 1 {
+		t.Errorf("expected InFlight 1 with MaxInFlight=1, got %d", stats.InFlight)
+	}
+
+	close(release)
+	wg.Wait()
+
+	stats := c.ClientStats()
+	if stats.Throttled == 0 {
+		t.Error("expected the second call to be counted as throttled while waiting for the semaphore")
+	}
+	if stats.InFlight != 0 {
+		t.Errorf("expected InFlight to settle back to 0, got %d", stats.InFlight)
+	}
+	if maxConcurrent != 1 {
+		t.Errorf("expected at most 1 concurrent request at the server, got %d", maxConcurrent)
+	}
+}
+
+func TestClientStatsCountsStreamingRequests(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var concurrent, maxConcurrent int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		concurrent++
+		if concurrent > maxConcurrent {
+			maxConcurrent = concurrent
+		}
+		mu.Unlock()
+
+		<-release
+
+		mu.Lock()
+		concurrent--
+		mu.Unlock()
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(200)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, &ClientConfig{MaxInFlight: 1})
+	defer c.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			events, err := c.AugmentStream(context.Background(), "q", nil)
+			if err != nil {
+				return
+			}
+			for range events {
+			}
+		}()
+	}
+
+	// Give both goroutines a chance to reach the server/semaphore.
+	time.Sleep(50 * time.Millisecond)
+	if stats := c.ClientStats(); stats.InFlight != 1 {
+		t.Errorf("expected InFlight 1 with MaxInFlight=1, got %d", stats.InFlight)
+	}
+
+	close(release)
+	wg.Wait()
+
+	if maxConcurrent != 1 {
+		t.Errorf("expected at most 1 concurrent streaming connection at the server, got %d", maxConcurrent)
+	}
+}
+
+func TestDoRequestHonorsRetryAfterWithoutPolicy(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(503)
+			return
+		}
+		w.WriteHeader(200)
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, &ClientConfig{
+		Retry: &RetryConfig{MaxRetries: 2, BaseDelayMs: 5000, MaxDelayMs: 5000},
+	})
+	defer c.Close()
+
+	start := time.Now()
+	if _, err := c.Health(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected Retry-After: 0 to override the 5s legacy backoff, took %v", elapsed)
+	}
+}
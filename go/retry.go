@@ -0,0 +1,239 @@
+package ucotron
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Jitter selects the built-in backoff shape RetryConfig uses when Policy is
+// not set. It has no effect once Policy is configured explicitly.
+type Jitter string
+
+const (
+	// JitterNone is the default: deterministic exponential backoff with no
+	// randomization (see Client.retryDelay).
+	JitterNone Jitter = "none"
+	// JitterFull backs off via ExponentialJitterPolicy, built from
+	// RetryConfig.BaseDelayMs/MaxDelayMs.
+	JitterFull Jitter = "full"
+	// JitterDecorrelated backs off via DecorrelatedJitterPolicy, built from
+	// RetryConfig.BaseDelayMs/MaxDelayMs.
+	JitterDecorrelated Jitter = "decorrelated"
+)
+
+// policyFor returns the RetryPolicy RetryConfig implies: Policy itself when
+// set, otherwise a policy constructed from Jitter/BaseDelayMs/MaxDelayMs, or
+// nil for JitterNone (the legacy exponential backoff in Client.retryDelay).
+func (rc RetryConfig) policyFor() RetryPolicy {
+	if rc.Policy != nil {
+		return rc.Policy
+	}
+	base := time.Duration(rc.BaseDelayMs) * time.Millisecond
+	cap := time.Duration(rc.MaxDelayMs) * time.Millisecond
+	switch rc.Jitter {
+	case JitterFull:
+		return ExponentialJitterPolicy{Base: base, Cap: cap}
+	case JitterDecorrelated:
+		return DecorrelatedJitterPolicy{Base: base, Cap: cap}
+	default:
+		return nil
+	}
+}
+
+// Clock abstracts time so that retry backoff can be exercised in tests
+// without waiting out real delays. The zero value of RetryConfig falls back
+// to realClock, which simply wraps the time package.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                        { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// RetryPolicy computes the delay before the next retry attempt. attempt is
+// the 0-based index of the attempt that just failed; resp is the HTTP
+// response that triggered the retry (nil for connection-level failures) and
+// err is the error doRequest produced for that attempt. When the returned
+// bool is false, the returned delay is ignored and the legacy exponential
+// backoff is used for that retry instead; it does not stop retrying, as the
+// attempt budget is set by RetryConfig.MaxRetries alone. The built-in
+// policies below always return true.
+type RetryPolicy interface {
+	NextDelay(attempt int, resp *http.Response, err error) (time.Duration, bool)
+}
+
+// RetryEligibility is an optional RetryPolicy extension that overrides
+// doRequest's default retry-eligibility check (5xx or 429). A RetryConfig's
+// policy that also implements ShouldRetry gets the final say on whether a
+// given non-2xx response is retried at all, before NextDelay is ever
+// consulted; connection failures are always retried without consulting it,
+// so resp is never nil. doRequest falls back to the 5xx-or-429 default for a policy
+// that doesn't implement it (including nil, i.e. JitterNone).
+type RetryEligibility interface {
+	ShouldRetry(attempt int, resp *http.Response, err error) bool
+}
+
+// defaultRetryEligible is doRequest's retry-eligibility check absent a
+// RetryEligibility-implementing policy: 5xx and 429 (rate limited) are
+// retried, every other 4xx is not.
+func defaultRetryEligible(resp *http.Response) bool {
+	return resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+}
+
+// retryEligible reports whether attempt's response/error should be retried,
+// deferring to the configured policy's ShouldRetry when it implements
+// RetryEligibility and falling back to defaultRetryEligible otherwise.
+func (c *Client) retryEligible(attempt int, resp *http.Response, err error) bool {
+	if policy, ok := c.retryConfig.policyFor().(RetryEligibility); ok {
+		return policy.ShouldRetry(attempt, resp, err)
+	}
+	return defaultRetryEligible(resp)
+}
+
+// ExponentialJitterPolicy implements "full jitter" exponential backoff, as
+// popularized by AWS's "Exponential Backoff and Jitter" guidance:
+// sleep = rand[0, min(Cap, Base*2^attempt)]. A Retry-After header on the
+// response, if present, overrides the computed delay.
+type ExponentialJitterPolicy struct {
+	Base time.Duration
+	Cap  time.Duration
+}
+
+// NextDelay implements RetryPolicy.
+func (p ExponentialJitterPolicy) NextDelay(attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if d, ok := retryAfterDelay(resp); ok {
+		return d, true
+	}
+
+	capped := float64(p.Base) * math.Pow(2, float64(attempt))
+	if capped > float64(p.Cap) {
+		capped = float64(p.Cap)
+	}
+	if capped <= 0 {
+		return 0, true
+	}
+	return time.Duration(rand.Int63n(int64(capped) + 1)), true
+}
+
+// DecorrelatedJitterPolicy implements "decorrelated jitter" backoff:
+// sleep = min(Cap, rand[Base, prev*3]). Since RetryPolicy does not carry
+// the previous sleep across calls, prev is reconstructed from attempt as
+// Base*3^attempt (capped), which approximates the same growth curve.
+type DecorrelatedJitterPolicy struct {
+	Base time.Duration
+	Cap  time.Duration
+}
+
+// NextDelay implements RetryPolicy.
+func (p DecorrelatedJitterPolicy) NextDelay(attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if d, ok := retryAfterDelay(resp); ok {
+		return d, true
+	}
+
+	prev := float64(p.Base)
+	for i := 0; i < attempt; i++ {
+		prev *= 3
+		if prev > float64(p.Cap) {
+			prev = float64(p.Cap)
+			break
+		}
+	}
+
+	lo := int64(p.Base)
+	hi := int64(prev * 3)
+	if hi > int64(p.Cap) {
+		hi = int64(p.Cap)
+	}
+	if hi <= lo {
+		return p.Base, true
+	}
+	return time.Duration(lo + rand.Int63n(hi-lo+1)), true
+}
+
+// FixedDelayPolicy retries after a constant delay, overridden by a
+// Retry-After header when present.
+type FixedDelayPolicy struct {
+	Delay time.Duration
+}
+
+// NextDelay implements RetryPolicy.
+func (p FixedDelayPolicy) NextDelay(attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if d, ok := retryAfterDelay(resp); ok {
+		return d, true
+	}
+	return p.Delay, true
+}
+
+// retryAfterDelay parses a Retry-After header off a 429 or 503 response,
+// supporting both the delta-seconds and HTTP-date forms.
+func retryAfterDelay(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, true
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// retryDelayFor computes the delay before the given attempt's retry. It uses
+// the policy implied by RetryConfig.Policy/Jitter, falling back to the legacy
+// exponential backoff for JitterNone or when the policy's NextDelay returns
+// false. A Retry-After header on resp wins under JitterNone and under every
+// built-in policy; a custom Policy's delay is used as it returns it. The
+// result is always capped by MaxDelayMs.
+func (c *Client) retryDelayFor(attempt int, resp *http.Response, err error) time.Duration {
+	delay := c.retryDelay(attempt)
+	if policy := c.retryConfig.policyFor(); policy != nil {
+		if d, ok := policy.NextDelay(attempt, resp, err); ok {
+			delay = d
+		}
+	} else if d, ok := retryAfterDelay(resp); ok {
+		delay = d
+	}
+	if cap := time.Duration(c.retryConfig.MaxDelayMs) * time.Millisecond; delay > cap {
+		delay = cap
+	}
+	return delay
+}
+
+// waitToRetryPolicy is like waitToRetry but consults the policy implied by
+// RetryConfig.Policy/Jitter (and any Retry-After header on resp) to compute
+// the delay instead of always using the legacy exponential backoff, and
+// waits on c.clock rather than the real time package.
+func (c *Client) waitToRetryPolicy(ctx context.Context, attempt, maxAttempts int, resp *http.Response, err error) bool {
+	if attempt >= maxAttempts-1 {
+		return true
+	}
+	delay := c.retryDelayFor(attempt, resp, err)
+	select {
+	case <-ctx.Done():
+		return false
+	case <-c.clock.After(delay):
+		return true
+	}
+}
@@ -0,0 +1,319 @@
+package ucotron
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryAfterDeltaSeconds(t *testing.T) {
+	resp := &http.Response{StatusCode: 429, Header: http.Header{"Retry-After": []string{"2"}}}
+	d, ok := retryAfterDelay(resp)
+	if !ok || d != 2*time.Second {
+		t.Errorf("expected 2s, got %v (ok=%v)", d, ok)
+	}
+}
+
+func TestRetryAfterHTTPDate(t *testing.T) {
+	future := time.Now().Add(3 * time.Second).UTC().Format(http.TimeFormat)
+	resp := &http.Response{StatusCode: 503, Header: http.Header{"Retry-After": []string{future}}}
+	d, ok := retryAfterDelay(resp)
+	if !ok {
+		t.Fatal("expected Retry-After to be parsed")
+	}
+	if d < 2*time.Second || d > 4*time.Second {
+		t.Errorf("expected ~3s, got %v", d)
+	}
+}
+
+func TestRetryAfterIgnoredForOtherStatuses(t *testing.T) {
+	resp := &http.Response{StatusCode: 500, Header: http.Header{"Retry-After": []string{"2"}}}
+	if _, ok := retryAfterDelay(resp); ok {
+		t.Error("expected Retry-After to be ignored for non-429/503 statuses")
+	}
+}
+
+func TestExponentialJitterPolicyWithinBounds(t *testing.T) {
+	p := ExponentialJitterPolicy{Base: 100 * time.Millisecond, Cap: 1 * time.Second}
+	d, ok := p.NextDelay(3, nil, nil)
+	if !ok {
+		t.Fatal("expected retry to be allowed")
+	}
+	if d < 0 || d > 1*time.Second {
+		t.Errorf("expected delay within [0, cap], got %v", d)
+	}
+}
+
+func TestDecorrelatedJitterPolicyWithinBounds(t *testing.T) {
+	p := DecorrelatedJitterPolicy{Base: 100 * time.Millisecond, Cap: 1 * time.Second}
+	d, ok := p.NextDelay(2, nil, nil)
+	if !ok {
+		t.Fatal("expected retry to be allowed")
+	}
+	if d < p.Base || d > p.Cap {
+		t.Errorf("expected delay within [base, cap], got %v", d)
+	}
+}
+
+func TestFixedDelayPolicyHonorsRetryAfter(t *testing.T) {
+	p := FixedDelayPolicy{Delay: 500 * time.Millisecond}
+	resp := &http.Response{StatusCode: 503, Header: http.Header{"Retry-After": []string{"1"}}}
+	d, ok := p.NextDelay(0, resp, nil)
+	if !ok || d != 1*time.Second {
+		t.Errorf("expected Retry-After to override fixed delay, got %v (ok=%v)", d, ok)
+	}
+}
+
+func TestRetryConfigJitterFullBuildsExponentialPolicy(t *testing.T) {
+	rc := RetryConfig{BaseDelayMs: 100, MaxDelayMs: 1000, Jitter: JitterFull}
+	p, ok := rc.policyFor().(ExponentialJitterPolicy)
+	if !ok {
+		t.Fatalf("expected JitterFull to build an ExponentialJitterPolicy, got %T", rc.policyFor())
+	}
+	if p.Base != 100*time.Millisecond || p.Cap != 1*time.Second {
+		t.Errorf("expected Base/Cap from BaseDelayMs/MaxDelayMs, got %+v", p)
+	}
+}
+
+func TestRetryConfigJitterDecorrelatedBuildsDecorrelatedPolicy(t *testing.T) {
+	rc := RetryConfig{BaseDelayMs: 100, MaxDelayMs: 1000, Jitter: JitterDecorrelated}
+	if _, ok := rc.policyFor().(DecorrelatedJitterPolicy); !ok {
+		t.Fatalf("expected JitterDecorrelated to build a DecorrelatedJitterPolicy, got %T", rc.policyFor())
+	}
+}
+
+func TestRetryConfigJitterNoneLeavesPolicyNil(t *testing.T) {
+	rc := RetryConfig{BaseDelayMs: 100, MaxDelayMs: 1000}
+	if p := rc.policyFor(); p != nil {
+		t.Errorf("expected no policy for the default JitterNone, got %T", p)
+	}
+}
+
+func TestRetryConfigExplicitPolicyWinsOverJitter(t *testing.T) {
+	want := FixedDelayPolicy{Delay: time.Second}
+	rc := RetryConfig{Policy: want, Jitter: JitterFull}
+	if got := rc.policyFor(); got != RetryPolicy(want) {
+		t.Errorf("expected the explicit Policy to win over Jitter, got %v", got)
+	}
+}
+
+// fakeClock fires After immediately regardless of the requested duration,
+// recording every requested delay so tests can assert on backoff shape
+// without actually waiting it out.
+type fakeClock struct {
+	mu     sync.Mutex
+	delays []time.Duration
+}
+
+func (f *fakeClock) Now() time.Time { return time.Time{} }
+
+func (f *fakeClock) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	f.delays = append(f.delays, d)
+	f.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	ch <- time.Time{}
+	return ch
+}
+
+func TestDoRequestHonorsRetryAfterViaFakeClockWithoutWaiting(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(503)
+			return
+		}
+		w.WriteHeader(200)
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer srv.Close()
+
+	clock := &fakeClock{}
+	c := NewClient(srv.URL, &ClientConfig{
+		Retry: &RetryConfig{MaxRetries: 1, BaseDelayMs: 1, MaxDelayMs: 5000, Clock: clock},
+	})
+	defer c.Close()
+
+	start := time.Now()
+	if _, err := c.Health(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Errorf("expected the fake clock to skip the real 2s wait, took %v", elapsed)
+	}
+	if len(clock.delays) != 1 || clock.delays[0] != 2*time.Second {
+		t.Errorf("expected a single recorded 2s delay from Retry-After, got %v", clock.delays)
+	}
+}
+
+func TestRetryBackoffDoesNotCollideAcrossCalls(t *testing.T) {
+	p := ExponentialJitterPolicy{Base: 10 * time.Millisecond, Cap: 10 * time.Second}
+	seen := make(map[time.Duration]bool)
+	distinct := 0
+	for i := 0; i < 20; i++ {
+		d, ok := p.NextDelay(5, nil, nil)
+		if !ok {
+			t.Fatal("expected retry to be allowed")
+		}
+		if !seen[d] {
+			seen[d] = true
+			distinct++
+		}
+	}
+	if distinct < 2 {
+		t.Errorf("expected jittered delays from repeated calls at the same attempt to vary, got %d distinct value(s) across 20 calls", distinct)
+	}
+}
+
+func TestDoRequest429IsRetried(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(200)
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, &ClientConfig{
+		Retry: &RetryConfig{MaxRetries: 1, BaseDelayMs: 1, MaxDelayMs: 5},
+	})
+	defer c.Close()
+
+	if _, err := c.Health(context.Background()); err != nil {
+		t.Fatalf("expected 429 to be retried and eventually succeed, got %v", err)
+	}
+	if atomic.LoadInt32(&attempts) != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestDoRequestOther4xxIsNotRetried(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, &ClientConfig{
+		Retry: &RetryConfig{MaxRetries: 2, BaseDelayMs: 1, MaxDelayMs: 5},
+	})
+	defer c.Close()
+
+	if _, err := c.Health(context.Background()); err == nil {
+		t.Fatal("expected an error for a 400 response")
+	}
+	if atomic.LoadInt32(&attempts) != 1 {
+		t.Errorf("expected exactly 1 attempt for a non-retryable 4xx, got %d", attempts)
+	}
+}
+
+// alwaysRetryPolicy implements RetryEligibility to retry every response,
+// including ones defaultRetryEligible would refuse (e.g. a 400), overriding
+// the default eligibility check entirely.
+type alwaysRetryPolicy struct {
+	FixedDelayPolicy
+}
+
+func (alwaysRetryPolicy) ShouldRetry(attempt int, resp *http.Response, err error) bool {
+	return true
+}
+
+func TestDoRequestCustomPolicyOverridesRetryEligibility(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(200)
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, &ClientConfig{
+		Retry: &RetryConfig{
+			MaxRetries: 1,
+			Policy:     alwaysRetryPolicy{FixedDelayPolicy{Delay: time.Millisecond}},
+		},
+	})
+	defer c.Close()
+
+	if _, err := c.Health(context.Background()); err != nil {
+		t.Fatalf("expected the custom policy to retry past the 400 and succeed, got %v", err)
+	}
+	if atomic.LoadInt32(&attempts) != 2 {
+		t.Errorf("expected 2 attempts with a policy that always retries, got %d", attempts)
+	}
+}
+
+func TestUcotronRetriesExhaustedErrorRecordsPerAttemptErrors(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, &ClientConfig{
+		Retry: &RetryConfig{MaxRetries: 2, BaseDelayMs: 1, MaxDelayMs: 5},
+	})
+	defer c.Close()
+
+	_, err := c.Health(context.Background())
+	var retriesErr *UcotronRetriesExhaustedError
+	if !errors.As(err, &retriesErr) {
+		t.Fatalf("expected UcotronRetriesExhaustedError, got %T: %v", err, err)
+	}
+	if retriesErr.Attempts() != 3 {
+		t.Errorf("expected 3 attempts, got %d", retriesErr.Attempts())
+	}
+	if len(retriesErr.Errors()) != 3 {
+		t.Errorf("expected one recorded error per attempt, got %d", len(retriesErr.Errors()))
+	}
+
+	var serverErr *UcotronServerError
+	if !errors.As(err, &serverErr) {
+		t.Error("expected errors.As to reach a UcotronServerError via multi-error Unwrap")
+	}
+}
+
+func TestDoRequestHonorsRetryAfterWithPolicy(t *testing.T) {
+	var callCount int32
+	start := time.Now()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := atomic.AddInt32(&callCount, 1)
+		if count == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(503)
+			return
+		}
+		w.WriteHeader(200)
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, &ClientConfig{
+		Retry: &RetryConfig{
+			MaxRetries: 1, BaseDelayMs: 1, MaxDelayMs: 5000,
+			Policy: ExponentialJitterPolicy{Base: 1 * time.Millisecond, Cap: 5 * time.Second},
+		},
+	})
+	defer c.Close()
+
+	if _, err := c.Health(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Errorf("expected the Retry-After delay (~1s) to be honored, only waited %v", elapsed)
+	}
+}
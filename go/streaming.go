@@ -0,0 +1,352 @@
+package ucotron
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// --- Streaming Event Types ---
+
+// AugmentEvent is a single incremental event emitted by AugmentStream.
+//
+// Exactly one of Memory, Entity, or ContextText is set depending on Type,
+// except for an "error" event, which sets only Err. If Err is non-nil, the stream has terminated abnormally and the channel
+// will be closed after this event.
+type AugmentEvent struct {
+	Type        string
+	Memory      *SearchResultItem
+	Entity      *EntityResponse
+	ContextText string
+	Err         error
+}
+
+// SearchEvent is a single incremental event emitted by SearchStream.
+//
+// If Err is non-nil, the stream has terminated abnormally and the channel
+// will be closed after this event.
+type SearchEvent struct {
+	Type   string
+	Result *SearchResultItem
+	Err    error
+}
+
+// sseEvent is a single parsed server-sent event before it is interpreted
+// by the caller into an AugmentEvent or SearchEvent.
+type sseEvent struct {
+	name string
+	data string
+}
+
+// readSSE reads one server-sent event (a run of lines terminated by a blank
+// line) from scanner. It returns false once the scanner is exhausted without
+// a further event; the caller checks scanner.Err() to tell a clean end of
+// stream from a read error.
+func readSSE(scanner *bufio.Scanner) (*sseEvent, bool) {
+	ev := &sseEvent{name: "message"}
+	var dataLines []string
+	sawAny := false
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if sawAny {
+				ev.data = strings.Join(dataLines, "\n")
+				return ev, true
+			}
+			continue
+		}
+		sawAny = true
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			ev.name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			dataLines = append(dataLines, strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		default:
+			// Ignore comments and unrecognized fields (e.g. "id:", "retry:").
+		}
+	}
+
+	if sawAny {
+		ev.data = strings.Join(dataLines, "\n")
+		return ev, true
+	}
+	return nil, false
+}
+
+// doRequestStream establishes a streaming request, retrying the initial
+// connection using the same policy as doRequest — including rotating across
+// cluster endpoints on failure, and respecting the client's RateLimiter and
+// MaxInFlight like every other call — and returns the raw HTTP response on
+// success. The MaxInFlight slot is held only until the response headers
+// arrive, not for the life of the stream. The caller owns resp.Body and must
+// close it. Once bytes have started flowing from a successful response, the
+// stream is not retried — a mid-stream disconnect surfaces as a terminal
+// error event.
+func (c *Client) doRequestStream(ctx context.Context, method, path, namespace string) (*http.Response, error) {
+	ns := c.resolveNamespace(namespace)
+
+	release, err := c.acquireInFlight(ctx)
+	if err != nil {
+		return nil, wrapIfCtxErr(err)
+	}
+	defer release()
+
+	var lastErr error
+	var lastResp *http.Response
+	var attemptErrs []error
+	endpointErrs := make(map[string]error)
+	maxAttempts := c.retryConfig.MaxRetries + 1
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		for try, numEndpoints := 0, c.numEndpoints(); try < numEndpoints; try++ {
+			if err := c.waitForRateLimit(ctx); err != nil {
+				return nil, wrapIfCtxErr(err)
+			}
+
+			endpoint := c.endpoint()
+			attemptStart := time.Now()
+
+			req, err := http.NewRequestWithContext(ctx, method, endpoint+path, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			req.Header.Set("Accept", "text/event-stream")
+			req.Header.Set(namespaceHeader, ns)
+
+			resp, err := c.httpClient.Do(req)
+			if err != nil {
+				c.observer.ObserveRequest(method, path, "error", time.Since(attemptStart))
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return nil, wrapIfCtxErr(ctxErr)
+				}
+				lastErr = &UcotronConnectionError{
+					Message: fmt.Sprintf("streaming request to %s %s failed", method, path),
+					Cause:   err,
+				}
+				lastResp = nil
+				endpointErrs[endpoint] = lastErr
+				c.rotateEndpoint()
+				continue
+			}
+			c.observer.ObserveRequest(method, path, strconv.Itoa(resp.StatusCode), time.Since(attemptStart))
+
+			if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+				return resp, nil
+			}
+
+			classifiedErr := classify(readErrorBody(resp))
+			if !c.retryEligible(attempt, resp, classifiedErr) {
+				return nil, classifiedErr
+			}
+
+			lastErr = classifiedErr
+			lastResp = resp
+			endpointErrs[endpoint] = lastErr
+			c.rotateEndpoint()
+		}
+
+		attemptErrs = append(attemptErrs, lastErr)
+
+		if !c.waitToRetryPolicy(ctx, attempt, maxAttempts, lastResp, lastErr) {
+			return nil, wrapIfCtxErr(ctx.Err())
+		}
+	}
+
+	return nil, &UcotronRetriesExhaustedError{
+		attempts:       maxAttempts,
+		errs:           attemptErrs,
+		LastError:      lastErr,
+		EndpointErrors: endpointErrs,
+	}
+}
+
+// AugmentStream is the streaming equivalent of Augment. It issues a request
+// against the server's SSE endpoint and emits incremental memory and entity
+// events as the server produces them, followed by a terminal event carrying
+// the assembled ContextText. The returned channel is closed when the stream
+// ends, whether normally, on error, or because ctx is canceled.
+func (c *Client) AugmentStream(ctx context.Context, contextText string, opts *AugmentOptions) (<-chan AugmentEvent, error) {
+	namespace := ""
+	limit := ""
+	var co CallOptions
+	if opts != nil {
+		namespace = opts.Namespace
+		co = opts.CallOptions
+		if opts.Limit != nil {
+			limit = fmt.Sprintf("&limit=%d", *opts.Limit)
+		}
+	}
+	ctx, cancel := withCallTimeout(ctx, co)
+
+	path := fmt.Sprintf("/api/v1/augment/stream?context=%s%s", urlQueryEscape(contextText), limit)
+	resp, err := c.doRequestStream(ctx, http.MethodGet, path, namespace)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	events := make(chan AugmentEvent)
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+		defer cancel()
+
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+		for {
+			ev, ok := readSSE(scanner)
+			if !ok {
+				if err := scanner.Err(); err != nil {
+					emitAugment(ctx, events, AugmentEvent{Type: "error", Err: err})
+				}
+				return
+			}
+
+			switch ev.name {
+			case "memory":
+				var item SearchResultItem
+				if err := json.Unmarshal([]byte(ev.data), &item); err != nil {
+					emitAugment(ctx, events, AugmentEvent{Type: "error", Err: err})
+					return
+				}
+				if !emitAugment(ctx, events, AugmentEvent{Type: "memory", Memory: &item}) {
+					return
+				}
+			case "entity":
+				var entity EntityResponse
+				if err := json.Unmarshal([]byte(ev.data), &entity); err != nil {
+					emitAugment(ctx, events, AugmentEvent{Type: "error", Err: err})
+					return
+				}
+				if !emitAugment(ctx, events, AugmentEvent{Type: "entity", Entity: &entity}) {
+					return
+				}
+			case "context":
+				var payload struct {
+					ContextText string `json:"context_text"`
+				}
+				if err := json.Unmarshal([]byte(ev.data), &payload); err != nil {
+					emitAugment(ctx, events, AugmentEvent{Type: "error", Err: err})
+					return
+				}
+				emitAugment(ctx, events, AugmentEvent{Type: "context", ContextText: payload.ContextText})
+			case "error":
+				serverErr := parseServerError(http.StatusInternalServerError, []byte(ev.data))
+				emitAugment(ctx, events, AugmentEvent{Type: "error", Err: serverErr})
+				return
+			case "done":
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// SearchStream is the streaming equivalent of Search. It emits one
+// SearchEvent per matching result as the server finds it, rather than
+// buffering the full result set before returning.
+func (c *Client) SearchStream(ctx context.Context, query string, opts *SearchOptions) (<-chan SearchEvent, error) {
+	namespace := ""
+	limit := ""
+	var co CallOptions
+	if opts != nil {
+		namespace = opts.Namespace
+		co = opts.CallOptions
+		if opts.Limit != nil {
+			limit = fmt.Sprintf("&limit=%d", *opts.Limit)
+		}
+	}
+	ctx, cancel := withCallTimeout(ctx, co)
+
+	path := fmt.Sprintf("/api/v1/memories/search/stream?query=%s%s", urlQueryEscape(query), limit)
+	resp, err := c.doRequestStream(ctx, http.MethodGet, path, namespace)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	events := make(chan SearchEvent)
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+		defer cancel()
+
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+		for {
+			ev, ok := readSSE(scanner)
+			if !ok {
+				if err := scanner.Err(); err != nil {
+					emitSearch(ctx, events, SearchEvent{Type: "error", Err: err})
+				}
+				return
+			}
+
+			switch ev.name {
+			case "result":
+				var item SearchResultItem
+				if err := json.Unmarshal([]byte(ev.data), &item); err != nil {
+					emitSearch(ctx, events, SearchEvent{Type: "error", Err: err})
+					return
+				}
+				if !emitSearch(ctx, events, SearchEvent{Type: "result", Result: &item}) {
+					return
+				}
+			case "error":
+				serverErr := parseServerError(http.StatusInternalServerError, []byte(ev.data))
+				emitSearch(ctx, events, SearchEvent{Type: "error", Err: serverErr})
+				return
+			case "done":
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// emitAugment sends ev on the channel, returning false if ctx was canceled
+// before the send could complete or if ev carries an error.
+func emitAugment(ctx context.Context, ch chan<- AugmentEvent, ev AugmentEvent) bool {
+	select {
+	case ch <- ev:
+		return ev.Err == nil
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// emitSearch sends ev on the channel, returning false if ctx was canceled
+// before the send could complete or if ev carries an error.
+func emitSearch(ctx context.Context, ch chan<- SearchEvent, ev SearchEvent) bool {
+	select {
+	case ch <- ev:
+		return ev.Err == nil
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// urlQueryEscape escapes s for inclusion in a URL query string.
+func urlQueryEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' || r == '.' || r == '~' {
+			b.WriteRune(r)
+			continue
+		}
+		for _, c := range []byte(string(r)) {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,104 @@
+package ucotron
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func sseHandler(events []string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(200)
+		flusher, _ := w.(http.Flusher)
+		for _, ev := range events {
+			fmt.Fprint(w, ev)
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+}
+
+func TestAugmentStream(t *testing.T) {
+	srv := httptest.NewServer(sseHandler([]string{
+		"event: memory\ndata: {\"id\":1,\"content\":\"fact one\",\"score\":0.9}\n\n",
+		"event: entity\ndata: {\"id\":2,\"content\":\"Juan\"}\n\n",
+		"event: context\ndata: {\"context_text\":\"assembled context\"}\n\n",
+		"event: done\ndata: \n\n",
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	defer c.Close()
+
+	events, err := c.AugmentStream(context.Background(), "test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []AugmentEvent
+	for ev := range events {
+		got = append(got, ev)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(got))
+	}
+	if got[0].Type != "memory" || got[0].Memory == nil || got[0].Memory.Content != "fact one" {
+		t.Errorf("unexpected first event: %+v", got[0])
+	}
+	if got[1].Type != "entity" || got[1].Entity == nil || got[1].Entity.Content != "Juan" {
+		t.Errorf("unexpected second event: %+v", got[1])
+	}
+	if got[2].Type != "context" || got[2].ContextText != "assembled context" {
+		t.Errorf("unexpected third event: %+v", got[2])
+	}
+}
+
+func TestSearchStream(t *testing.T) {
+	srv := httptest.NewServer(sseHandler([]string{
+		"event: result\ndata: {\"id\":1,\"content\":\"a\",\"score\":0.5}\n\n",
+		"event: result\ndata: {\"id\":2,\"content\":\"b\",\"score\":0.4}\n\n",
+		"event: done\ndata: \n\n",
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	defer c.Close()
+
+	events, err := c.SearchStream(context.Background(), "q", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var results []SearchResultItem
+	for ev := range events {
+		if ev.Err != nil {
+			t.Fatalf("unexpected stream error: %v", ev.Err)
+		}
+		results = append(results, *ev.Result)
+	}
+
+	if len(results) != 2 || results[0].Content != "a" || results[1].Content != "b" {
+		t.Errorf("unexpected results: %+v", results)
+	}
+}
+
+func TestAugmentStreamServerError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+		fmt.Fprint(w, `{"code":"NOT_FOUND","message":"no such namespace"}`)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	defer c.Close()
+
+	_, err := c.AugmentStream(context.Background(), "test", nil)
+	if err == nil {
+		t.Fatal("expected error establishing stream")
+	}
+}
@@ -13,6 +13,8 @@
 //	fmt.Println(result.ContextText)
 package ucotron
 
+import "encoding/json"
+
 // --- Memory Types ---
 
 // CreateMemoryRequest is the request body for creating a new memory.
@@ -124,9 +126,10 @@ type LearnRequest struct {
 
 // LearnResponse contains counts of items created during learning.
 type LearnResponse struct {
-	MemoriesCreated int `json:"memories_created"`
-	EntitiesFound   int `json:"entities_found"`
-	ConflictsFound  int `json:"conflicts_found"`
+	MemoriesCreated int              `json:"memories_created"`
+	EntitiesFound   int              `json:"entities_found"`
+	ConflictsFound  int              `json:"conflicts_found"`
+	Metrics         IngestionMetrics `json:"metrics"`
 }
 
 // --- Health & Metrics Types ---
@@ -165,6 +168,8 @@ type MetricsResponse struct {
 
 // APIErrorBody represents the JSON error response from the server.
 type APIErrorBody struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code      string          `json:"code"`
+	Message   string          `json:"message"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	RequestID string          `json:"request_id,omitempty"`
 }
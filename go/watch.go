@@ -0,0 +1,249 @@
+package ucotron
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// --- Watch Event Types ---
+
+// MemoryEvent is a single change to a memory, emitted by WatchMemories.
+// Type is "created", "updated", or "deleted". Rev is a monotonically
+// increasing revision that can be passed as WatchOptions.Since to resume a
+// later watch from this point.
+type MemoryEvent struct {
+	Type   string          `json:"type"`
+	Rev    int64           `json:"rev"`
+	Memory *MemoryResponse `json:"memory"`
+}
+
+// EntityEvent is a single change to an entity, emitted by WatchEntities.
+// Type is "created", "updated", or "deleted". Rev is a monotonically
+// increasing revision that can be passed as WatchOptions.Since to resume a
+// later watch from this point.
+type EntityEvent struct {
+	Type   string          `json:"type"`
+	Rev    int64           `json:"rev"`
+	Entity *EntityResponse `json:"entity"`
+}
+
+// WatchOptions are optional parameters for WatchMemories and WatchEntities.
+type WatchOptions struct {
+	// Since resumes the watch from just after this revision. Zero starts
+	// from the server's current state.
+	Since     int64
+	Namespace string
+	CallOptions
+}
+
+// WatchMemories subscribes to a long-lived stream of memory changes,
+// starting from opts.Since (or the server's current state if zero). The
+// returned event channel is closed once the watch ends for any reason; the
+// returned error channel receives at most one terminal error — a malformed
+// event, a non-2xx server response or a reconnection failure — and is
+// closed alongside it.
+// A transient disconnect is retried automatically, using the client's
+// retry/backoff configuration and resuming from the last observed Rev;
+// canceling ctx stops the watch and closes both channels without an error.
+func (c *Client) WatchMemories(ctx context.Context, opts *WatchOptions) (<-chan MemoryEvent, <-chan error, error) {
+	namespace := ""
+	var since int64
+	var co CallOptions
+	if opts != nil {
+		namespace = opts.Namespace
+		since = opts.Since
+		co = opts.CallOptions
+	}
+	ctx, cancel := withCallTimeout(ctx, co)
+
+	resp, err := c.doRequestStream(ctx, http.MethodGet, memoryWatchPath(since), namespace)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	events := make(chan MemoryEvent)
+	errs := make(chan error, 1)
+	go func() {
+		defer cancel()
+		defer close(events)
+		defer close(errs)
+
+		for {
+			var ok bool
+			var readErr error
+			since, ok, readErr = readMemoryEvents(ctx, resp, events, since)
+			if ok {
+				if readErr != nil {
+					emitWatchErr(ctx, errs, readErr)
+				}
+				return
+			}
+
+			resp, err = c.doRequestStream(ctx, http.MethodGet, memoryWatchPath(since), namespace)
+			if err != nil {
+				if ctx.Err() == nil {
+					emitWatchErr(ctx, errs, err)
+				}
+				return
+			}
+		}
+	}()
+
+	return events, errs, nil
+}
+
+// readMemoryEvents decodes SSE events from resp until the stream ends,
+// emitting each as a MemoryEvent. It returns the last observed revision,
+// whether the watch should stop entirely (true) rather than reconnect, and a
+// non-nil error if it stopped because of a malformed event rather than a
+// clean end of stream or ctx cancellation.
+func readMemoryEvents(ctx context.Context, resp *http.Response, events chan<- MemoryEvent, since int64) (int64, bool, error) {
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for {
+		ev, ok := readSSE(scanner)
+		if !ok {
+			return since, ctx.Err() != nil, nil
+		}
+
+		var payload MemoryEvent
+		if err := json.Unmarshal([]byte(ev.data), &payload); err != nil {
+			return since, true, fmt.Errorf("ucotron: malformed memory watch event: %w", err)
+		}
+		since = payload.Rev
+		if !emitMemoryEvent(ctx, events, payload) {
+			return since, true, nil
+		}
+	}
+}
+
+// WatchEntities subscribes to a long-lived stream of entity changes,
+// starting from opts.Since (or the server's current state if zero). The
+// returned event channel is closed once the watch ends for any reason; the
+// returned error channel receives at most one terminal error — a malformed
+// event, a non-2xx server response or a reconnection failure — and is
+// closed alongside it.
+// A transient disconnect is retried automatically, using the client's
+// retry/backoff configuration and resuming from the last observed Rev;
+// canceling ctx stops the watch and closes both channels without an error.
+func (c *Client) WatchEntities(ctx context.Context, opts *WatchOptions) (<-chan EntityEvent, <-chan error, error) {
+	namespace := ""
+	var since int64
+	var co CallOptions
+	if opts != nil {
+		namespace = opts.Namespace
+		since = opts.Since
+		co = opts.CallOptions
+	}
+	ctx, cancel := withCallTimeout(ctx, co)
+
+	resp, err := c.doRequestStream(ctx, http.MethodGet, entityWatchPath(since), namespace)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	events := make(chan EntityEvent)
+	errs := make(chan error, 1)
+	go func() {
+		defer cancel()
+		defer close(events)
+		defer close(errs)
+
+		for {
+			var ok bool
+			var readErr error
+			since, ok, readErr = readEntityEvents(ctx, resp, events, since)
+			if ok {
+				if readErr != nil {
+					emitWatchErr(ctx, errs, readErr)
+				}
+				return
+			}
+
+			resp, err = c.doRequestStream(ctx, http.MethodGet, entityWatchPath(since), namespace)
+			if err != nil {
+				if ctx.Err() == nil {
+					emitWatchErr(ctx, errs, err)
+				}
+				return
+			}
+		}
+	}()
+
+	return events, errs, nil
+}
+
+// readEntityEvents decodes SSE events from resp until the stream ends,
+// emitting each as an EntityEvent. It returns the last observed revision,
+// whether the watch should stop entirely (true) rather than reconnect, and a
+// non-nil error if it stopped because of a malformed event rather than a
+// clean end of stream or ctx cancellation.
+func readEntityEvents(ctx context.Context, resp *http.Response, events chan<- EntityEvent, since int64) (int64, bool, error) {
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for {
+		ev, ok := readSSE(scanner)
+		if !ok {
+			return since, ctx.Err() != nil, nil
+		}
+
+		var payload EntityEvent
+		if err := json.Unmarshal([]byte(ev.data), &payload); err != nil {
+			return since, true, fmt.Errorf("ucotron: malformed entity watch event: %w", err)
+		}
+		since = payload.Rev
+		if !emitEntityEvent(ctx, events, payload) {
+			return since, true, nil
+		}
+	}
+}
+
+func memoryWatchPath(since int64) string {
+	return fmt.Sprintf("/api/v1/memories/watch?since=%d", since)
+}
+
+func entityWatchPath(since int64) string {
+	return fmt.Sprintf("/api/v1/entities/watch?since=%d", since)
+}
+
+// emitMemoryEvent sends ev on the channel, returning false if ctx was
+// canceled before the send could complete.
+func emitMemoryEvent(ctx context.Context, ch chan<- MemoryEvent, ev MemoryEvent) bool {
+	select {
+	case ch <- ev:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// emitEntityEvent sends ev on the channel, returning false if ctx was
+// canceled before the send could complete.
+func emitEntityEvent(ctx context.Context, ch chan<- EntityEvent, ev EntityEvent) bool {
+	select {
+	case ch <- ev:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// emitWatchErr sends err on the error channel, dropping it if ctx was
+// canceled before the send could complete.
+func emitWatchErr(ctx context.Context, ch chan<- error, err error) {
+	select {
+	case ch <- err:
+	case <-ctx.Done():
+	}
+}
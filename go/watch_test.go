@@ -0,0 +1,171 @@
+package ucotron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWatchMemoriesDeliversEventsAndResumesAfterDisconnect(t *testing.T) {
+	var connections int32
+	sinceSeen := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&connections, 1)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(200)
+		flusher, _ := w.(http.Flusher)
+
+		if n == 1 {
+			fmt.Fprint(w, "data: {\"type\":\"created\",\"rev\":1,\"memory\":{\"id\":1,\"content\":\"a\"}}\n\n")
+			if flusher != nil {
+				flusher.Flush()
+			}
+			return // simulate a transient disconnect
+		}
+
+		sinceSeen <- r.URL.Query().Get("since")
+		fmt.Fprint(w, "data: {\"type\":\"updated\",\"rev\":2,\"memory\":{\"id\":1,\"content\":\"b\"}}\n\n")
+		if flusher != nil {
+			flusher.Flush()
+		}
+		<-r.Context().Done() // hold the connection open until the test cancels
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, errs, err := c.WatchMemories(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ev1 := <-events
+	if ev1.Type != "created" || ev1.Rev != 1 || ev1.Memory == nil || ev1.Memory.Content != "a" {
+		t.Fatalf("unexpected first event: %+v", ev1)
+	}
+
+	if since := <-sinceSeen; since != "1" {
+		t.Errorf("expected reconnect to resume from rev 1, got since=%q", since)
+	}
+
+	ev2 := <-events
+	if ev2.Type != "updated" || ev2.Rev != 2 {
+		t.Fatalf("unexpected second event: %+v", ev2)
+	}
+
+	cancel()
+	if _, ok := <-events; ok {
+		t.Error("expected events channel to close after ctx is canceled")
+	}
+	if _, ok := <-errs; ok {
+		t.Error("expected no error after a clean cancellation")
+	}
+}
+
+func TestWatchMemoriesServerErrorIsTerminal(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+		fmt.Fprint(w, `{"code":"NOT_FOUND","message":"no such namespace"}`)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	defer c.Close()
+
+	_, _, err := c.WatchMemories(context.Background(), nil)
+	if err == nil {
+		t.Fatal("expected error establishing the initial watch connection")
+	}
+	var serverErr *UcotronServerError
+	if !errors.As(err, &serverErr) {
+		t.Fatalf("expected UcotronServerError, got %T: %v", err, err)
+	}
+}
+
+func TestWatchEntitiesDeliversEvents(t *testing.T) {
+	srv := httptest.NewServer(sseHandler([]string{
+		"data: {\"type\":\"created\",\"rev\":1,\"entity\":{\"id\":7,\"content\":\"Juan\"}}\n\n",
+		"data: {\"type\":\"deleted\",\"rev\":2,\"entity\":{\"id\":7}}\n\n",
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, _, err := c.WatchEntities(ctx, &WatchOptions{Since: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ev1 := <-events
+	if ev1.Type != "created" || ev1.Rev != 1 || ev1.Entity == nil || ev1.Entity.Content != "Juan" {
+		t.Fatalf("unexpected first event: %+v", ev1)
+	}
+	ev2 := <-events
+	if ev2.Type != "deleted" || ev2.Rev != 2 {
+		t.Fatalf("unexpected second event: %+v", ev2)
+	}
+}
+
+func TestWatchMemoriesMalformedEventIsTerminal(t *testing.T) {
+	srv := httptest.NewServer(sseHandler([]string{
+		"data: {\"type\":\"created\",\"rev\":1,\"memory\":{\"id\":1,\"content\":\"a\"}}\n\n",
+		"data: {not valid json}\n\n",
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	defer c.Close()
+
+	events, errs, err := c.WatchMemories(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ev1 := <-events
+	if ev1.Type != "created" || ev1.Rev != 1 {
+		t.Fatalf("unexpected first event: %+v", ev1)
+	}
+
+	if _, ok := <-events; ok {
+		t.Error("expected events channel to close after a malformed event")
+	}
+	watchErr, ok := <-errs
+	if !ok || watchErr == nil {
+		t.Fatal("expected the malformed event to surface as a terminal error")
+	}
+}
+
+func TestWatchEntitiesMalformedEventIsTerminal(t *testing.T) {
+	srv := httptest.NewServer(sseHandler([]string{
+		"data: {not valid json}\n\n",
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, nil)
+	defer c.Close()
+
+	events, errs, err := c.WatchEntities(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := <-events; ok {
+		t.Error("expected events channel to close after a malformed event")
+	}
+	watchErr, ok := <-errs
+	if !ok || watchErr == nil {
+		t.Fatal("expected the malformed event to surface as a terminal error")
+	}
+}